@@ -0,0 +1,5 @@
+data "spacelift_gpg_keys" "keys" {}
+
+output "active-gpg-key-ids" {
+  value = [for key in data.spacelift_gpg_keys.keys.keys : key.id if !key.revoked]
+}
@@ -0,0 +1,7 @@
+data "spacelift_module_versions" "k8s-module" {
+  module_id = "k8s-module"
+}
+
+output "latest-k8s-module-version" {
+  value = one([for v in data.spacelift_module_versions.k8s-module.versions : v.number if v.latest])
+}
@@ -0,0 +1,13 @@
+data "spacelift_modules" "k8s" {
+  labels {
+    any_of = ["k8s", "kubernetes"]
+  }
+
+  space {
+    any_of = ["platform"]
+  }
+
+  terraform_provider {
+    any_of = ["aws"]
+  }
+}
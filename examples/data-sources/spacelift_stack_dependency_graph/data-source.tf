@@ -0,0 +1,7 @@
+data "spacelift_stack_dependency_graph" "infra" {
+  stack_ids = ["k8s-cluster", "k8s-core"]
+}
+
+output "deployment-order" {
+  value = data.spacelift_stack_dependency_graph.infra.topological_order
+}
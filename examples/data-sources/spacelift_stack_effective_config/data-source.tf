@@ -0,0 +1,7 @@
+data "spacelift_stack_effective_config" "k8s-core" {
+  stack_id = "k8s-core"
+}
+
+output "shadowed-config" {
+  value = [for element in data.spacelift_stack_effective_config.k8s-core.config : element if element.shadowed]
+}
@@ -0,0 +1,8 @@
+data "spacelift_stack_state" "k8s-core" {
+  stack_id    = "k8s-core"
+  output_path = "${path.root}/backups/k8s-core.tfstate"
+}
+
+output "k8s-core-state-serial" {
+  value = data.spacelift_stack_state.k8s-core.serial
+}
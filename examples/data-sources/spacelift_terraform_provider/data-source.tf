@@ -0,0 +1,7 @@
+data "spacelift_terraform_provider" "datadog" {
+  type = "datadog"
+}
+
+output "active-datadog-versions" {
+  value = [for v in data.spacelift_terraform_provider.datadog.versions : v.number if v.status == "ACTIVE"]
+}
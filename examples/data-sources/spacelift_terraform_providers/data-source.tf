@@ -0,0 +1,5 @@
+data "spacelift_terraform_providers" "internal" {
+  labels {
+    any_of = ["internal"]
+  }
+}
@@ -2,3 +2,11 @@ resource "spacelift_context" "prod-k8s-ie" {
   description = "Configuration details for the compute cluster in 🇮🇪"
   name        = "Production cluster (Ireland)"
 }
+
+# Takes over the context if it was already created outside of Terraform.
+resource "spacelift_context" "prod-k8s-us" {
+  adopt_existing = true
+  description    = "Configuration details for the compute cluster in 🇺🇸"
+  name           = "Production cluster (US)"
+  space_id       = "root"
+}
@@ -0,0 +1,5 @@
+terraform import spacelift_environment_variables.ireland context/$CONTEXT_ID
+
+terraform import spacelift_environment_variables.k8s-module module/$MODULE_ID
+
+terraform import spacelift_environment_variables.k8s-core stack/$STACK_ID
@@ -0,0 +1,17 @@
+resource "spacelift_environment_variables" "k8s-core" {
+  stack_id = "k8s-core"
+
+  # Delete any variables on the stack which are not declared below.
+  exclusive = true
+
+  variable {
+    name       = "KUBECONFIG"
+    value      = "/project/spacelift/kubeconfig"
+    write_only = false
+  }
+
+  variable {
+    name  = "KUBE_TOKEN"
+    value = var.kube_token
+  }
+}
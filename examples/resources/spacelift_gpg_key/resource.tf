@@ -0,0 +1,15 @@
+resource "spacelift_gpg_key" "release" {
+  name        = "release"
+  description = "Signs internal provider releases"
+  ascii_armor = file("${path.module}/release.asc")
+}
+
+resource "spacelift_terraform_provider_version" "datadog" {
+  provider_id = "datadog"
+  version     = "1.2.3"
+  gpg_key_id  = spacelift_gpg_key.release.id
+
+  platform_files  = ["dist/terraform-provider-datadog_1.2.3_linux_amd64.zip"]
+  sha256sums_file = "dist/terraform-provider-datadog_1.2.3_SHA256SUMS"
+  signature_file  = "dist/terraform-provider-datadog_1.2.3_SHA256SUMS.sig"
+}
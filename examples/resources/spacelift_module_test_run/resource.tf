@@ -0,0 +1,13 @@
+resource "spacelift_module_test_run" "k8s-module" {
+  module_id  = "k8s-module"
+  commit_sha = var.commit_sha
+
+  wait {
+    # Report failing test cases through "passed" rather than failing the apply.
+    continue_on_state = ["finished", "failed"]
+  }
+}
+
+output "k8s-module-tests-passed" {
+  value = spacelift_module_test_run.k8s-module.passed
+}
@@ -18,3 +18,17 @@ resource "spacelift_mounted_file" "core-kubeconfig" {
   relative_path = "kubeconfig"
   content       = filebase64("${path.module}/kubeconfig.json")
 }
+
+# From a local file, keeping its content out of the plan and the state
+resource "spacelift_mounted_file" "apps-kubeconfig" {
+  stack_id      = "k8s-apps"
+  relative_path = "kubeconfig"
+  source_path   = "${path.module}/kubeconfig.json"
+}
+
+# From a local directory, mounting every file in it under a prefix
+resource "spacelift_mounted_file" "core-manifests" {
+  stack_id      = "k8s-core"
+  relative_path = "manifests"
+  source_dir    = "${path.module}/manifests"
+}
@@ -0,0 +1 @@
+terraform import spacelift_run.this $STACK_ID/$RUN_ID
@@ -0,0 +1 @@
+terraform import spacelift_stack_dependencies.app $STACK_ID
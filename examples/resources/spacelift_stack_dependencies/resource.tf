@@ -0,0 +1,45 @@
+resource "spacelift_stack" "network" {
+  branch     = "master"
+  name       = "Network stack"
+  repository = "core-infra"
+}
+
+resource "spacelift_stack" "database" {
+  branch     = "master"
+  name       = "Database stack"
+  repository = "core-infra"
+}
+
+resource "spacelift_stack" "app" {
+  branch     = "master"
+  name       = "Application stack"
+  repository = "app"
+}
+
+resource "spacelift_stack_dependencies" "app" {
+  stack_id = spacelift_stack.app.id
+
+  dependency {
+    depends_on_stack_id = spacelift_stack.network.id
+
+    reference {
+      output_name = "vpc_id"
+      input_name  = "TF_VAR_vpc_id"
+    }
+
+    reference {
+      output_name = "subnet_ids"
+      input_name  = "network/subnet_ids.json"
+      type        = "FILE_MOUNT"
+    }
+  }
+
+  dependency {
+    depends_on_stack_id = spacelift_stack.database.id
+
+    reference {
+      output_name = "connection_string"
+      input_name  = "APP_DB_URL"
+    }
+  }
+}
@@ -19,4 +19,10 @@ resource "spacelift_stack_dependency_reference" "test" {
   stack_dependency_id = spacelift_stack_dependency.test.id
   output_name         = "DB_CONNECTION_STRING"
   input_name          = "APP_DB_URL"
-}
\ No newline at end of file
+}
+resource "spacelift_stack_dependency_reference" "kubeconfig" {
+  stack_dependency_id = spacelift_stack_dependency.test.id
+  output_name         = "kubeconfig"
+  input_name          = "config/kubeconfig.yaml"
+  type                = "FILE_MOUNT"
+}
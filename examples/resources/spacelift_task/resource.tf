@@ -0,0 +1,18 @@
+resource "spacelift_stack" "this" {
+  name       = "Test stack"
+  repository = "test"
+  branch     = "main"
+}
+
+resource "spacelift_task" "this" {
+  stack_id = spacelift_stack.this.id
+  command  = "terraform state mv aws_instance.old aws_instance.new"
+
+  keepers = {
+    migration = "rename-instance"
+  }
+
+  wait {
+    continue_on_state = ["finished"]
+  }
+}
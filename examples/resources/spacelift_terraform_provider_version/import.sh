@@ -0,0 +1 @@
+terraform import spacelift_terraform_provider_version.datadog $PROVIDER_ID/$VERSION_ID
@@ -0,0 +1,13 @@
+locals {
+  dist = "${path.module}/dist"
+}
+
+resource "spacelift_terraform_provider_version" "datadog" {
+  provider_id = spacelift_terraform_provider.datadog.id
+  version     = "1.2.3"
+  gpg_key_id  = "01HXYZ"
+
+  platform_files  = [for file in fileset(local.dist, "*.zip") : "${local.dist}/${file}"]
+  sha256sums_file = "${local.dist}/terraform-provider-datadog_1.2.3_SHA256SUMS"
+  signature_file  = "${local.dist}/terraform-provider-datadog_1.2.3_SHA256SUMS.sig"
+}
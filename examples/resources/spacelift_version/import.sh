@@ -0,0 +1 @@
+terraform import spacelift_version.k8s-module $MODULE_ID/$VERSION_ID
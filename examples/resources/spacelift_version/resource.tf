@@ -0,0 +1,7 @@
+resource "spacelift_version" "k8s-module" {
+  module_id      = "k8s-module"
+  version_number = "1.2.0"
+
+  # Yanks the version once it's no longer managed.
+  yank_on_destroy = true
+}
@@ -0,0 +1,75 @@
+package spacelift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+)
+
+// adoptExistingSchema has no default so that it doesn't get in the way of
+// importing resources.
+func adoptExistingSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeBool,
+		Description: fmt.Sprintf(""+
+			"Adopt an existing %[1]s with the same ID (slug) if creating it fails "+
+			"because it already exists, instead of failing. The existing %[1]s must "+
+			"be in the configured space (or the `root` space if none is configured) "+
+			"and is updated to match the configuration. Default: `false`", kind),
+		Optional: true,
+	}
+}
+
+// adoptLookupFunc returns the space of the existing entity with the given ID,
+// or false if there is none. It returns an error if the entity exists but
+// can't be adopted.
+type adoptLookupFunc func(ctx context.Context, client *internal.Client, id string) (space string, found bool, err error)
+
+// adoptExisting takes over the existing entity with the given ID after its
+// creation failed because of a conflict, and updates it to match the
+// configuration. In any other case the creation failure is returned as is.
+func adoptExisting(ctx context.Context, d *schema.ResourceData, meta interface{}, kind, id string, err error, createErr diag.Diagnostics, lookup adoptLookupFunc, update schema.UpdateContextFunc) diag.Diagnostics {
+	if !internal.IsConflictError(err, id) {
+		return createErr
+	}
+
+	space, found, err := lookup(ctx, meta.(*internal.Client), id)
+	if err != nil {
+		return append(createErr, diag.Errorf("could not adopt existing %s %s: %v", kind, id, internal.FromSpaceliftError(err))...)
+	}
+
+	if !found {
+		return createErr
+	}
+
+	// Entities created without an explicit space end up in the root one.
+	expected := "root"
+	if spaceID, ok := d.GetOk("space_id"); ok {
+		expected = spaceID.(string)
+	}
+
+	if expected != space {
+		return append(createErr, diag.Errorf("%s %s already exists in space %s instead of %s, refusing to adopt it", kind, id, space, expected)...)
+	}
+
+	tflog.Info(ctx, "adopting existing entity", map[string]any{
+		"kind": kind,
+		"id":   id,
+	})
+
+	// The update needs the ID, but if it fails the entity must not end up in
+	// the state, or it would be tainted and destroyed on the next apply.
+	d.SetId(id)
+
+	if diags := update(ctx, d, meta); diags.HasError() {
+		d.SetId("")
+		return append(createErr, diags...)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package spacelift
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+)
+
+func dataGPGKeys() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_gpg_keys` represents all the GPG keys registered for " +
+			"verifying the signatures of Terraform provider versions, including " +
+			"revoked ones.",
+
+		ReadContext: dataGPGKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"keys": {
+				Type:        schema.TypeList,
+				Description: "Registered GPG keys",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "ID of the key",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the key",
+							Computed:    true,
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Description: "Free-form description of the key",
+							Computed:    true,
+						},
+						"created_at": {
+							Type:        schema.TypeInt,
+							Description: "Unix timestamp at which the key was registered",
+							Computed:    true,
+						},
+						"revoked": {
+							Type:        schema.TypeBool,
+							Description: "Whether the key is revoked",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataGPGKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keys, err := getGPGKeys(ctx, meta.(*internal.Client))
+	if err != nil {
+		return diag.Errorf("could not query for GPG keys: %v", internal.FromSpaceliftError(err))
+	}
+
+	d.SetId("spacelift-gpg-keys")
+
+	flattened := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		flattened = append(flattened, map[string]interface{}{
+			"id":          key.ID,
+			"name":        key.Name,
+			"description": key.Description,
+			"created_at":  key.CreatedAt,
+			"revoked":     key.RevokedAt != nil,
+		})
+	}
+
+	if err := d.Set("keys", flattened); err != nil {
+		return diag.Errorf("could not set GPG keys: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package spacelift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestGPGKeysData(t *testing.T) {
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	asciiArmor, _ := testGPGKey(t)
+
+	testSteps(t, []resource.TestStep{{
+		Config: fmt.Sprintf(`
+			resource "spacelift_gpg_key" "test" {
+				name        = "provider-test-key-%s"
+				ascii_armor = <<EOF
+%sEOF
+			}
+
+			data "spacelift_gpg_keys" "test" {
+				depends_on = [spacelift_gpg_key.test]
+			}
+		`, randomID, asciiArmor),
+		Check: Resource(
+			"data.spacelift_gpg_keys.test",
+			Attribute("id", Equals("spacelift-gpg-keys")),
+			Nested("keys",
+				CheckInList(
+					Attribute("name", Equals(fmt.Sprintf("provider-test-key-%s", randomID))),
+					Attribute("revoked", Equals("false")),
+				),
+			),
+		),
+	}})
+}
@@ -0,0 +1,277 @@
+package spacelift
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+func dataModuleVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_module_versions` represents all the versions of a module, " +
+			"including failed ones, along with the inputs, outputs and providers " +
+			"each of them declares.",
+
+		ReadContext: dataModuleVersionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"module_id": {
+				Type:             schema.TypeString,
+				Description:      "ID of the module",
+				Required:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Description: "Versions of the module, newest first",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: moduleVersionFields(),
+				},
+			},
+		},
+	}
+}
+
+// moduleVersionFields are the computed attributes describing a module version.
+func moduleVersionFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "ID of the version",
+			Computed:    true,
+		},
+		"number": {
+			Type:        schema.TypeString,
+			Description: "Semantic version number",
+			Computed:    true,
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Description: "State of the version, e.g. `ACTIVE` or `FAILED`",
+			Computed:    true,
+		},
+		"commit_sha": {
+			Type:        schema.TypeString,
+			Description: "SHA of the commit the version was created from",
+			Computed:    true,
+		},
+		"commit_message": {
+			Type:        schema.TypeString,
+			Description: "Message of the commit the version was created from",
+			Computed:    true,
+		},
+		"created_at": {
+			Type:        schema.TypeInt,
+			Description: "Unix timestamp at which the version was created",
+			Computed:    true,
+		},
+		"latest": {
+			Type:        schema.TypeBool,
+			Description: "Whether this is the highest `ACTIVE` version which isn't yanked",
+			Computed:    true,
+		},
+		"yanked": {
+			Type:        schema.TypeBool,
+			Description: "Whether the version is yanked",
+			Computed:    true,
+		},
+		"inputs": {
+			Type:        schema.TypeList,
+			Description: "Input variables declared by the root module",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the input",
+						Computed:    true,
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Description: "Type of the input",
+						Computed:    true,
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Description: "Description of the input",
+						Computed:    true,
+					},
+					"default": {
+						Type:        schema.TypeString,
+						Description: "Default value of the input, encoded as JSON. Not set if the input has no default.",
+						Computed:    true,
+					},
+					"required": {
+						Type:        schema.TypeBool,
+						Description: "Whether the input has to be set",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"outputs": {
+			Type:        schema.TypeList,
+			Description: "Outputs declared by the root module",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the output",
+						Computed:    true,
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Description: "Description of the output",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"providers": {
+			Type:        schema.TypeList,
+			Description: "Providers required by the root module",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Local name of the provider",
+						Computed:    true,
+					},
+					"namespace": {
+						Type:        schema.TypeString,
+						Description: "Namespace of the provider",
+						Computed:    true,
+					},
+					"source": {
+						Type:        schema.TypeString,
+						Description: "Source address of the provider",
+						Computed:    true,
+					},
+					"version": {
+						Type:        schema.TypeString,
+						Description: "Version constraint for the provider",
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataModuleVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var query struct {
+		Module *struct {
+			Versions []structs.Version `graphql:"versions(includeFailed: true)"`
+		} `graphql:"module(id: $id)"`
+	}
+
+	moduleID := d.Get("module_id").(string)
+	variables := map[string]interface{}{"id": toID(moduleID)}
+
+	if err := meta.(*internal.Client).Query(ctx, "ModuleVersionsRead", &query, variables); err != nil {
+		return diag.Errorf("could not query for module versions: %v", internal.FromSpaceliftError(err))
+	}
+
+	if query.Module == nil {
+		return diag.Errorf("module not found")
+	}
+
+	latest := latestModuleVersion(query.Module.Versions)
+
+	versions := make([]interface{}, 0, len(query.Module.Versions))
+	for _, v := range query.Module.Versions {
+		version := flattenModuleVersion(&v)
+		version["latest"] = v.ID == latest
+		versions = append(versions, version)
+	}
+
+	d.SetId(moduleID)
+
+	if err := d.Set("versions", versions); err != nil {
+		return diag.Errorf("could not set versions: %v", err)
+	}
+
+	return nil
+}
+
+func flattenModuleVersion(v *structs.Version) map[string]interface{} {
+	inputs, outputs, providers := []interface{}{}, []interface{}{}, []interface{}{}
+
+	if v.Metadata != nil {
+		for _, input := range v.Metadata.Root.Inputs {
+			flattened := map[string]interface{}{
+				"name":        input.Name,
+				"type":        input.Type,
+				"description": input.Description,
+				"required":    input.Required,
+			}
+			if input.Default != nil {
+				flattened["default"] = *input.Default
+			}
+			inputs = append(inputs, flattened)
+		}
+
+		for _, output := range v.Metadata.Root.Outputs {
+			outputs = append(outputs, map[string]interface{}{
+				"name":        output.Name,
+				"description": output.Description,
+			})
+		}
+
+		for _, provider := range v.Metadata.Root.ProviderDependencies {
+			providers = append(providers, map[string]interface{}{
+				"name":      provider.Name,
+				"namespace": provider.Namespace,
+				"source":    provider.Source,
+				"version":   provider.Version,
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"id":             v.ID,
+		"number":         v.Number,
+		"state":          v.State,
+		"commit_sha":     v.Commit.Hash,
+		"commit_message": v.Commit.Message,
+		"created_at":     v.CreatedAt,
+		"yanked":         v.Yanked,
+		"inputs":         inputs,
+		"outputs":        outputs,
+		"providers":      providers,
+	}
+}
+
+// latestModuleVersion returns the ID of the highest active version which
+// isn't yanked, or an empty string if there is none.
+func latestModuleVersion(versions []structs.Version) string {
+	var latestID string
+	var latest *version.Version
+
+	for _, v := range versions {
+		if v.State != "ACTIVE" || v.Yanked {
+			continue
+		}
+
+		number, err := version.NewVersion(v.Number)
+		if err != nil {
+			continue
+		}
+
+		if latest == nil || number.GreaterThan(latest) {
+			latestID, latest = v.ID, number
+		}
+	}
+
+	return latestID
+}
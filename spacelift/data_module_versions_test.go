@@ -0,0 +1,49 @@
+package spacelift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestModuleVersionsData(t *testing.T) {
+	const resourceName = "data.spacelift_module_versions.test"
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	testSteps(t, []resource.TestStep{{
+		Config: fmt.Sprintf(`
+			resource "spacelift_module" "test" {
+				name           = "test-versions-module-%s"
+				administrative = true
+				branch         = "module"
+				repository     = "terraform-bacon-tasty"
+			}
+
+			resource "spacelift_version" "test" {
+				module_id      = spacelift_module.test.id
+				version_number = "0.1.0"
+			}
+
+			data "spacelift_module_versions" "test" {
+				module_id  = spacelift_module.test.id
+				depends_on = [spacelift_version.test]
+			}
+		`, randomID),
+		Check: Resource(
+			resourceName,
+			Attribute("id", Equals(fmt.Sprintf("test-versions-module-%s", randomID))),
+			Attribute("versions.#", Equals("1")),
+			Attribute("versions.0.number", Equals("0.1.0")),
+			Attribute("versions.0.state", Equals("ACTIVE")),
+			Attribute("versions.0.latest", Equals("true")),
+			Attribute("versions.0.yanked", Equals("false")),
+			Attribute("versions.0.commit_sha", IsNotEmpty()),
+			Attribute("versions.0.created_at", IsNotEmpty()),
+		),
+	}})
+}
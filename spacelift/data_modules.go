@@ -0,0 +1,147 @@
+package spacelift
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/graphql"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs/search"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs/search/predicates"
+)
+
+func dataModules() *schema.Resource {
+	moduleSchema := dataModule().Schema
+
+	moduleSchema["module_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "ID (slug) of the module",
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_modules` represents all the modules in the Spacelift " +
+			"account visible to the API user, matching predicates.",
+
+		ReadContext: dataModulesRead,
+
+		Schema: map[string]*schema.Schema{
+			// Search predicates.
+			"administrative":     predicates.BooleanField("Require modules to be administrative or not", 1),
+			"branch":             predicates.StringField("Require modules to be on one of the branches", 1),
+			"labels":             predicates.StringField("Require modules to have one of the labels", 0),
+			"name":               predicates.StringField("Require modules to have one of the names", 1),
+			"project_root":       predicates.StringField("Require modules to be in one of the project roots", 1),
+			"repository":         predicates.StringField("Require modules to be in one of the repositories", 1),
+			"space":              predicates.StringField("Require modules to be in one of the spaces", 1),
+			"terraform_provider": predicates.StringField("Require modules to be for one of the Terraform providers", 1),
+			"worker_pool":        predicates.StringField("Require modules to use one of the worker pools", 1),
+
+			// Results.
+			"modules": {
+				Type:        schema.TypeList,
+				Description: "List of modules matching the predicates",
+				Elem:        &schema.Resource{Schema: moduleSchema},
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataModulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Build the conditions.
+	var conditions []search.SearchQueryPredicate
+
+	conditions = append(conditions, predicates.BuildBoolean(d, "administrative")...)
+	conditions = append(conditions, predicates.BuildStringOrEnum(d, false, "branch")...)
+	conditions = append(conditions, predicates.BuildStringOrEnum(d, false, "labels", "label")...)
+	conditions = append(conditions, predicates.BuildStringOrEnum(d, false, "name")...)
+	conditions = append(conditions, predicates.BuildStringOrEnum(d, false, "project_root", "projectRoot")...)
+	conditions = append(conditions, predicates.BuildStringOrEnum(d, false, "repository")...)
+	conditions = append(conditions, predicates.BuildStringOrEnum(d, false, "space")...)
+	conditions = append(conditions, predicates.BuildStringOrEnum(d, false, "terraform_provider", "terraformProvider")...)
+	conditions = append(conditions, predicates.BuildStringOrEnum(d, false, "worker_pool", "workerPool")...)
+
+	var query struct {
+		SearchModulesOutput struct {
+			Edges []struct {
+				Node structs.Module `graphql:"node"`
+			} `graphql:"edges"`
+			PageInfo search.PageInfo `graphql:"pageInfo"`
+		} `graphql:"searchModules(input: $input)"`
+	}
+
+	input := search.SearchInput{
+		First:      graphql.NewInt(50),
+		Predicates: &conditions,
+	}
+
+	var modules []interface{}
+
+	for {
+		variables := map[string]interface{}{"input": input}
+
+		if err := meta.(*internal.Client).Query(ctx, "ModulesPage", &query, variables); err != nil {
+			return diag.Errorf("could not query for modules: %v", err)
+		}
+
+		for _, edge := range query.SearchModulesOutput.Edges {
+			node := edge.Node
+
+			module := map[string]interface{}{
+				"administrative":                   node.Administrative,
+				"aws_assume_role_policy_statement": node.Integrations.AWS.AssumeRolePolicyStatement,
+				"branch":                           node.Branch,
+				"description":                      node.Description,
+				"enable_local_preview":             node.LocalPreviewEnabled,
+				"labels":                           node.Labels,
+				"module_id":                        node.ID,
+				"name":                             node.Name,
+				"project_root":                     node.ProjectRoot,
+				"protect_from_deletion":            node.ProtectFromDeletion,
+				"repository":                       node.Repository,
+				"shared_accounts":                  node.SharedAccounts,
+				"space_id":                         node.Space,
+				"terraform_provider":               node.TerraformProvider,
+				"workflow_tool":                    node.WorkflowTool,
+			}
+
+			if workerPool := node.WorkerPool; workerPool != nil {
+				module["worker_pool_id"] = workerPool.ID
+			} else {
+				module["worker_pool_id"] = nil
+			}
+
+			if vcsKey, vcsSettings := node.VCSSettings(); vcsKey != "" {
+				vcsValue := []interface{}{vcsSettings}
+				if vcsSettings == nil {
+					vcsValue = nil
+				}
+				module[vcsKey] = vcsValue
+			}
+
+			modules = append(modules, module)
+		}
+
+		if !query.SearchModulesOutput.PageInfo.HasNextPage {
+			break
+		}
+
+		after := graphql.String(query.SearchModulesOutput.PageInfo.EndCursor)
+		input.After = &after
+	}
+
+	d.SetId(fmt.Sprintf("modules-%d", time.Now().UnixNano()))
+
+	if err := d.Set("modules", modules); err != nil {
+		return diag.Errorf("could not set modules: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package spacelift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestModulesData(t *testing.T) {
+	t.Run("reads the modules collection", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		resourceName := "spacelift_module.test"
+		datasourceName := "data.spacelift_modules.test"
+
+		testSteps(t, []resource.TestStep{{
+			Config: fmt.Sprintf(`
+				resource "spacelift_module" "test" {
+					name               = "test-modules-%s"
+					administrative     = true
+					branch             = "master"
+					labels             = ["bacon", "cabbage"]
+					repository         = "terraform-bacon-tasty"
+					space_id           = "root"
+					terraform_provider = "bacon"
+				}
+
+				data "spacelift_modules" "test" {
+					depends_on = [spacelift_module.test]
+
+					labels {
+					  any_of = ["bacon"]
+					}
+
+					name {
+					  any_of = ["test-modules-%s"]
+					}
+
+					repository {
+					  any_of = ["terraform-bacon-tasty"]
+					}
+
+					space {
+					  any_of = ["root"]
+					}
+
+					terraform_provider {
+					  any_of = ["bacon"]
+					}
+				}
+			`, randomID, randomID),
+			Check: resource.ComposeTestCheckFunc(
+				Resource(datasourceName, Attribute("id", IsNotEmpty())),
+				CheckIfResourceNestedAttributeContainsResourceAttribute(datasourceName, []string{"modules", "module_id"}, resourceName, "id"),
+				CheckIfResourceNestedAttributeContainsResourceAttribute(datasourceName, []string{"modules", "name"}, resourceName, "name"),
+			),
+		}})
+	})
+}
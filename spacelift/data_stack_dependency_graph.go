@@ -0,0 +1,117 @@
+package spacelift
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+)
+
+func dataStackDependencyGraph() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_stack_dependency_graph` represents the graph of " +
+			"dependencies between the given stacks and all the stacks they are " +
+			"connected to, directly or transitively, in either direction.",
+
+		ReadContext: dataStackDependencyGraphRead,
+
+		Schema: map[string]*schema.Schema{
+			"stack_ids": {
+				Type:        schema.TypeSet,
+				Description: "IDs (slugs) of the stacks to start exploring the graph from",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Description: "IDs of all the stacks in the graph",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"edges": {
+				Type:        schema.TypeList,
+				Description: "Dependencies between the stacks in the graph",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"stack_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the stack which has a dependency",
+							Computed:    true,
+						},
+						"depends_on_stack_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the stack it depends on",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"topological_order": {
+				Type:        schema.TypeList,
+				Description: "IDs of the stacks in the graph, each one after all the stacks it depends on. Empty if the graph contains a cycle.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"cycle": {
+				Type:        schema.TypeList,
+				Description: "A dependency cycle in the graph, if any, as a path of stack IDs starting and ending with the same stack",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataStackDependencyGraphRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var stackIDs []string
+	for _, stackID := range d.Get("stack_ids").(*schema.Set).List() {
+		stackIDs = append(stackIDs, stackID.(string))
+	}
+	slices.Sort(stackIDs)
+
+	graph := newStackDependencyGraph(meta.(*internal.Client))
+
+	if err := graph.loadConnected(ctx, stackIDs); err != nil {
+		return diag.Errorf("could not load stack dependency graph: %v", internal.FromSpaceliftError(err))
+	}
+
+	nodes := graph.nodes()
+
+	var edges []interface{}
+	for _, stackID := range nodes {
+		for _, dependsOnStackID := range graph.dependsOn[stackID] {
+			edges = append(edges, map[string]interface{}{
+				"stack_id":            stackID,
+				"depends_on_stack_id": dependsOnStackID,
+			})
+		}
+	}
+
+	order, cycle := graph.topologicalOrder()
+
+	d.SetId(strings.Join(stackIDs, ","))
+	d.Set("nodes", nodes)
+	d.Set("topological_order", order)
+	d.Set("cycle", cycle)
+
+	if err := d.Set("edges", edges); err != nil {
+		return diag.Errorf("could not set edges: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package spacelift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestStackDependencyGraphData(t *testing.T) {
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlpha)
+
+	testSteps(t, []resource.TestStep{{
+		Config: fmt.Sprintf(`
+			resource "spacelift_stack" "first" {
+				branch     = "master"
+				repository = "demo"
+				name       = "graph-first-%s"
+			}
+
+			resource "spacelift_stack" "second" {
+				branch     = "master"
+				repository = "demo"
+				name       = "graph-second-%s"
+			}
+
+			resource "spacelift_stack" "third" {
+				branch     = "master"
+				repository = "demo"
+				name       = "graph-third-%s"
+			}
+
+			resource "spacelift_stack_dependency" "second_on_first" {
+				stack_id            = spacelift_stack.second.id
+				depends_on_stack_id = spacelift_stack.first.id
+			}
+
+			resource "spacelift_stack_dependency" "third_on_second" {
+				stack_id            = spacelift_stack.third.id
+				depends_on_stack_id = spacelift_stack.second.id
+			}
+
+			data "spacelift_stack_dependency_graph" "test" {
+				stack_ids = [spacelift_stack.second.id]
+
+				depends_on = [
+					spacelift_stack_dependency.second_on_first,
+					spacelift_stack_dependency.third_on_second,
+				]
+			}
+		`, randomID, randomID, randomID),
+		Check: Resource(
+			"data.spacelift_stack_dependency_graph.test",
+			SetEquals("nodes", "graph-first-"+randomID, "graph-second-"+randomID, "graph-third-"+randomID),
+			Attribute("edges.#", Equals("2")),
+			Attribute("topological_order.0", Equals("graph-first-"+randomID)),
+			Attribute("topological_order.1", Equals("graph-second-"+randomID)),
+			Attribute("topological_order.2", Equals("graph-third-"+randomID)),
+			Attribute("cycle.#", Equals("0")),
+		),
+	}})
+}
@@ -0,0 +1,171 @@
+package spacelift
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+func dataStackEffectiveConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_stack_effective_config` represents all the environment " +
+			"variables and mounted files a stack's runs will see, whether " +
+			"they're defined on the stack itself or on one of the attached " +
+			"contexts (`spacelift_context`), in order of precedence. Definitions " +
+			"overridden by one with a higher precedence are flagged as shadowed.",
+
+		ReadContext: dataStackEffectiveConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"stack_id": {
+				Type:             schema.TypeString,
+				Description:      "ID of the stack",
+				Required:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"config": {
+				Type:        schema.TypeList,
+				Description: "Config elements of the stack and its attached contexts, in order of precedence",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the environment variable or relative path of the mounted file",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Type of the config element, either `ENVIRONMENT_VARIABLE` or `FILE_MOUNT`",
+							Computed:    true,
+						},
+						"write_only": {
+							Type:        schema.TypeBool,
+							Description: "Indicates whether the value can be read back outside a Run",
+							Computed:    true,
+						},
+						"checksum": {
+							Type:        schema.TypeString,
+							Description: "SHA-256 checksum of the value",
+							Computed:    true,
+						},
+						"source": {
+							Type:        schema.TypeString,
+							Description: "Where the config element is defined, either `stack` or `context`",
+							Computed:    true,
+						},
+						"context_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the context on which the config element is defined, if any",
+							Computed:    true,
+						},
+						"priority": {
+							Type:        schema.TypeInt,
+							Description: "Priority of the attachment of the context on which the config element is defined, if any",
+							Computed:    true,
+						},
+						"autoattached": {
+							Type:        schema.TypeBool,
+							Description: "Indicates whether the context on which the config element is defined is attached automatically based on labels",
+							Computed:    true,
+						},
+						"shadowed": {
+							Type:        schema.TypeBool,
+							Description: "Indicates whether the config element is overridden by another one with the same name and a higher precedence",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataStackEffectiveConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var query struct {
+		Stack *struct {
+			Config           []structs.ConfigElement `graphql:"config"`
+			AttachedContexts []struct {
+				ContextID      string                  `graphql:"contextId"`
+				IsAutoattached bool                    `graphql:"isAutoattached"`
+				Priority       int                     `graphql:"priority"`
+				Config         []structs.ConfigElement `graphql:"config"`
+			} `graphql:"attachedContexts"`
+		} `graphql:"stack(id: $id)"`
+	}
+
+	stackID := d.Get("stack_id").(string)
+	variables := map[string]interface{}{"id": toID(stackID)}
+
+	if err := meta.(*internal.Client).Query(ctx, "StackEffectiveConfigRead", &query, variables); err != nil {
+		return diag.Errorf("could not query for stack config: %v", internal.FromSpaceliftError(err))
+	}
+
+	if query.Stack == nil {
+		return diag.Errorf("stack not found")
+	}
+
+	// Elements defined on the stack always win. Between contexts, the ones
+	// attached with the lowest priority take precedence.
+	attachments := query.Stack.AttachedContexts
+	sort.SliceStable(attachments, func(i, j int) bool {
+		return attachments[i].Priority < attachments[j].Priority
+	})
+
+	type elementKey struct {
+		id         string
+		configType structs.ConfigType
+	}
+
+	seen := make(map[elementKey]bool)
+	var config []interface{}
+
+	appendElements := func(elements []structs.ConfigElement, source map[string]interface{}) {
+		sort.SliceStable(elements, func(i, j int) bool { return elements[i].ID < elements[j].ID })
+
+		for _, element := range elements {
+			key := elementKey{id: element.ID, configType: element.Type}
+
+			item := map[string]interface{}{
+				"name":       element.ID,
+				"type":       string(element.Type),
+				"write_only": element.WriteOnly,
+				"checksum":   element.Checksum,
+				"shadowed":   seen[key],
+			}
+
+			for k, v := range source {
+				item[k] = v
+			}
+
+			seen[key] = true
+			config = append(config, item)
+		}
+	}
+
+	appendElements(query.Stack.Config, map[string]interface{}{"source": "stack"})
+
+	for _, attachment := range attachments {
+		appendElements(attachment.Config, map[string]interface{}{
+			"source":       "context",
+			"context_id":   attachment.ContextID,
+			"priority":     attachment.Priority,
+			"autoattached": attachment.IsAutoattached,
+		})
+	}
+
+	d.SetId(stackID)
+
+	if err := d.Set("config", config); err != nil {
+		return diag.Errorf("could not set config: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package spacelift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestStackEffectiveConfigData(t *testing.T) {
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	testSteps(t, []resource.TestStep{{
+		Config: fmt.Sprintf(`
+			resource "spacelift_stack" "test" {
+				branch     = "master"
+				repository = "demo"
+				name       = "Test stack %s"
+			}
+
+			resource "spacelift_context" "test" {
+				name = "Test context %s"
+			}
+
+			resource "spacelift_context_attachment" "test" {
+				context_id = spacelift_context.test.id
+				stack_id   = spacelift_stack.test.id
+				priority   = 1
+			}
+
+			resource "spacelift_environment_variable" "stack" {
+				stack_id = spacelift_stack.test.id
+				name     = "BACON"
+				value    = "is tasty"
+			}
+
+			resource "spacelift_environment_variables" "context" {
+				context_id = spacelift_context.test.id
+
+				variable {
+					name  = "BACON"
+					value = "is crispy"
+				}
+
+				variable {
+					name       = "CABBAGE"
+					value      = "is healthy"
+					write_only = false
+				}
+			}
+
+			data "spacelift_stack_effective_config" "test" {
+				stack_id = spacelift_context_attachment.test.stack_id
+
+				depends_on = [
+					spacelift_environment_variable.stack,
+					spacelift_environment_variables.context,
+				]
+			}
+		`, randomID, randomID),
+		Check: Resource(
+			"data.spacelift_stack_effective_config.test",
+			Attribute("id", Contains(randomID)),
+			Attribute("config.#", Equals("3")),
+			Attribute("config.0.name", Equals("BACON")),
+			Attribute("config.0.type", Equals("ENVIRONMENT_VARIABLE")),
+			Attribute("config.0.source", Equals("stack")),
+			Attribute("config.0.checksum", Equals("4d5d01ea427b10dd483e8fce5b5149fb5a9814e9ee614176b756ca4a65c8f154")),
+			Attribute("config.0.shadowed", Equals("false")),
+			Attribute("config.1.name", Equals("BACON")),
+			Attribute("config.1.source", Equals("context")),
+			Attribute("config.1.context_id", Contains(randomID)),
+			Attribute("config.1.priority", Equals("1")),
+			Attribute("config.1.shadowed", Equals("true")),
+			Attribute("config.2.name", Equals("CABBAGE")),
+			Attribute("config.2.write_only", Equals("false")),
+			Attribute("config.2.shadowed", Equals("false")),
+		),
+	}})
+}
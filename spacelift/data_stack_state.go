@@ -0,0 +1,159 @@
+package spacelift
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/tfstate"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+func dataStackState() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_stack_state` downloads the current Terraform state of a " +
+			"stack whose state is managed by Spacelift. The state is either " +
+			"written to a local file or exposed as a sensitive attribute, e.g. " +
+			"to take a backup before destructive operations.",
+
+		ReadContext: dataStackStateRead,
+
+		Schema: map[string]*schema.Schema{
+			"stack_id": {
+				Type:             schema.TypeString,
+				Description:      "ID (slug) of the stack",
+				Required:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"output_path": {
+				Type:        schema.TypeString,
+				Description: "Local path to write the state to. If not set, the state is exposed in the `content` attribute instead.",
+				Optional:    true,
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Description: "Content of the state, unless `output_path` is set",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"lineage": {
+				Type:        schema.TypeString,
+				Description: "Lineage of the state",
+				Computed:    true,
+			},
+			"serial": {
+				Type:        schema.TypeInt,
+				Description: "Serial number of the state",
+				Computed:    true,
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Description: "Format version of the state",
+				Computed:    true,
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Description: "Size of the state in bytes",
+				Computed:    true,
+			},
+			"sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA-256 checksum of the state",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataStackStateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var query struct {
+		Stack *struct {
+			ManagesStateFile bool `graphql:"managesStateFile"`
+			StateDownloadURL *struct {
+				URL string `graphql:"url"`
+			} `graphql:"stateDownloadUrl"`
+		} `graphql:"stack(id: $id)"`
+	}
+
+	stackID := d.Get("stack_id").(string)
+	variables := map[string]interface{}{"id": toID(stackID)}
+
+	client := meta.(*internal.Client)
+	if err := client.Query(ctx, "StackStateDownloadURL", &query, variables); err != nil {
+		return diag.Errorf("could not query for stack state: %v", internal.FromSpaceliftError(err))
+	}
+
+	if query.Stack == nil {
+		return diag.Errorf("stack not found")
+	}
+
+	if !query.Stack.ManagesStateFile {
+		return diag.Errorf("stack %s does not have its state managed by Spacelift", stackID)
+	}
+
+	if query.Stack.StateDownloadURL == nil {
+		return diag.Errorf("stack %s does not have any state yet", stackID)
+	}
+
+	body, err := client.Download(ctx, query.Stack.StateDownloadURL.URL)
+	if err != nil {
+		return diag.Errorf("could not download state of stack %s: %v", stackID, err)
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	var content bytes.Buffer
+	var output io.Writer = &content
+
+	outputPath := d.Get("output_path").(string)
+	var file *os.File
+	if outputPath != "" {
+		// Write to a temporary file first, so that an existing backup is
+		// only replaced by a complete and valid one.
+		if file, err = os.CreateTemp(filepath.Dir(outputPath), filepath.Base(outputPath)+".*"); err != nil {
+			return diag.Errorf("could not create state file: %v", err)
+		}
+		defer os.Remove(file.Name())
+		defer file.Close()
+		output = file
+	}
+
+	metadata, size, err := tfstate.Inspect(io.TeeReader(body, io.MultiWriter(output, hash)))
+	if err != nil {
+		return diag.Errorf("invalid state of stack %s: %v", stackID, err)
+	}
+
+	if file != nil {
+		if err := closeAndRename(file, outputPath); err != nil {
+			return diag.Errorf("could not write state file: %v", err)
+		}
+	}
+
+	d.SetId(stackID)
+	d.Set("content", content.String())
+	d.Set("lineage", metadata.Lineage)
+	d.Set("serial", metadata.Serial)
+	d.Set("version", metadata.Version)
+	d.Set("size", size)
+	d.Set("sha256", hex.EncodeToString(hash.Sum(nil)))
+
+	return nil
+}
+
+func closeAndRename(file *os.File, path string) error {
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return errors.Wrap(os.Rename(file.Name(), path), "could not move state file into place")
+}
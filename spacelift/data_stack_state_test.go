@@ -0,0 +1,62 @@
+package spacelift
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestStackStateData(t *testing.T) {
+	t.Run("with imported state", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{{
+			Config: fmt.Sprintf(`
+				resource "spacelift_stack" "test" {
+					branch       = "master"
+					import_state = jsonencode({ version = 4, lineage = "2c8f2b4f-2a1f-4b7a-9d43-0c7f6d1a6b1e", serial = 3 })
+					name         = "Test stack %s"
+					repository   = "demo"
+				}
+
+				data "spacelift_stack_state" "test" {
+					stack_id = spacelift_stack.test.id
+				}
+			`, randomID),
+			Check: Resource(
+				"data.spacelift_stack_state.test",
+				Attribute("id", StartsWith("test-stack-")),
+				Attribute("content", IsNotEmpty()),
+				Attribute("lineage", Equals("2c8f2b4f-2a1f-4b7a-9d43-0c7f6d1a6b1e")),
+				Attribute("serial", Equals("3")),
+				Attribute("version", Equals("4")),
+				Attribute("sha256", IsNotEmpty()),
+			),
+		}})
+	})
+
+	t.Run("without managed state", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{{
+			Config: fmt.Sprintf(`
+				resource "spacelift_stack" "test" {
+					branch       = "master"
+					manage_state = false
+					name         = "Test stack %s"
+					repository   = "demo"
+				}
+
+				data "spacelift_stack_state" "test" {
+					stack_id = spacelift_stack.test.id
+				}
+			`, randomID),
+			ExpectError: regexp.MustCompile("does not have its state managed by Spacelift"),
+		}})
+	})
+}
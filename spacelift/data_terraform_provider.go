@@ -0,0 +1,142 @@
+package spacelift
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+// terraformProviderWithVersions is a Terraform provider along with all its
+// versions.
+type terraformProviderWithVersions struct {
+	structs.TerraformProvider
+	Versions []structs.TerraformProviderVersion `graphql:"versions"`
+}
+
+func dataTerraformProvider() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_terraform_provider` represents a Terraform provider in " +
+			"Spacelift's own provider registry, along with its versions.",
+
+		ReadContext: dataTerraformProviderRead,
+
+		Schema: terraformProviderFields(&schema.Schema{
+			Type:             schema.TypeString,
+			Description:      "Type of the provider",
+			Required:         true,
+			ValidateDiagFunc: validations.DisallowEmptyString,
+		}),
+	}
+}
+
+// terraformProviderFields are the attributes describing a Terraform provider,
+// identified by the given type attribute.
+func terraformProviderFields(providerType *schema.Schema) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": providerType,
+		"space_id": {
+			Type:        schema.TypeString,
+			Description: "ID (slug) of the space the provider is in",
+			Computed:    true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Free-form description for human users",
+			Computed:    true,
+		},
+		"labels": {
+			Type:     schema.TypeSet,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Computed: true,
+		},
+		"public": {
+			Type:        schema.TypeBool,
+			Description: "Whether the provider is public or not",
+			Computed:    true,
+		},
+		"versions": {
+			Type:        schema.TypeList,
+			Description: "Versions of the provider",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Description: "ID of the version",
+						Computed:    true,
+					},
+					"number": {
+						Type:        schema.TypeString,
+						Description: "Semantic version number",
+						Computed:    true,
+					},
+					"protocols": {
+						Type:        schema.TypeList,
+						Description: "Terraform plugin protocol versions supported by the version",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Computed:    true,
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Description: "Status of the version, e.g. `ACTIVE` or `REVOKED`",
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataTerraformProviderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var query struct {
+		TerraformProvider *terraformProviderWithVersions `graphql:"terraformProvider(id: $id)"`
+	}
+
+	providerType := d.Get("type").(string)
+	variables := map[string]interface{}{"id": toID(providerType)}
+
+	if err := meta.(*internal.Client).Query(ctx, "TerraformProviderRead", &query, variables); err != nil {
+		return diag.Errorf("could not query for Terraform provider: %v", internal.FromSpaceliftError(err))
+	}
+
+	if query.TerraformProvider == nil {
+		return diag.Errorf("Terraform provider not found")
+	}
+
+	d.SetId(providerType)
+
+	for key, value := range flattenTerraformProvider(query.TerraformProvider) {
+		if err := d.Set(key, value); err != nil {
+			return diag.Errorf("could not set %s: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+func flattenTerraformProvider(provider *terraformProviderWithVersions) map[string]interface{} {
+	versions := make([]interface{}, 0, len(provider.Versions))
+	for _, version := range provider.Versions {
+		versions = append(versions, map[string]interface{}{
+			"id":        version.ID,
+			"number":    version.Number,
+			"protocols": version.Protocols,
+			"status":    version.Status,
+		})
+	}
+
+	return map[string]interface{}{
+		"type":        provider.ID,
+		"space_id":    provider.Space,
+		"description": provider.Description,
+		"labels":      provider.Labels,
+		"public":      provider.Public,
+		"versions":    versions,
+	}
+}
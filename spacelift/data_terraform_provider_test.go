@@ -0,0 +1,39 @@
+package spacelift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestTerraformProviderData(t *testing.T) {
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlpha)
+
+	testSteps(t, []resource.TestStep{{
+		Config: fmt.Sprintf(`
+			resource "spacelift_terraform_provider" "test" {
+				type        = "%s"
+				space_id    = "root"
+				description = "test provider"
+				labels      = ["one", "two"]
+			}
+
+			data "spacelift_terraform_provider" "test" {
+				type = spacelift_terraform_provider.test.id
+			}
+		`, randomID),
+		Check: Resource(
+			"data.spacelift_terraform_provider.test",
+			Attribute("id", Equals(randomID)),
+			Attribute("space_id", Equals("root")),
+			Attribute("description", Equals("test provider")),
+			SetEquals("labels", "one", "two"),
+			Attribute("public", Equals("false")),
+			Attribute("versions.#", Equals("0")),
+		),
+	}})
+}
@@ -0,0 +1,79 @@
+package spacelift
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs/search/predicates"
+)
+
+func dataTerraformProviders() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_terraform_providers` represents all the Terraform " +
+			"providers in Spacelift's own provider registry visible to the API " +
+			"user, along with their versions.",
+
+		ReadContext: dataTerraformProvidersRead,
+
+		Schema: map[string]*schema.Schema{
+			"labels": predicates.StringField("Require providers to have one of the labels", 0),
+			"providers": {
+				Type:        schema.TypeList,
+				Description: "List of providers",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: terraformProviderFields(&schema.Schema{
+						Type:        schema.TypeString,
+						Description: "Type of the provider",
+						Computed:    true,
+					}),
+				},
+			},
+		},
+	}
+}
+
+func dataTerraformProvidersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var query struct {
+		TerraformProviders []*terraformProviderWithVersions `graphql:"terraformProviders"`
+	}
+
+	if err := meta.(*internal.Client).Query(ctx, "TerraformProvidersRead", &query, map[string]interface{}{}); err != nil {
+		return diag.Errorf("could not query for Terraform providers: %v", internal.FromSpaceliftError(err))
+	}
+
+	var labelFilters [][]string
+
+	for _, labelFilter := range d.Get("labels").([]interface{}) {
+		possibleValues := labelFilter.(map[string]interface{})["any_of"]
+		if possibleValues == nil {
+			continue
+		}
+
+		var labels []string
+		for _, label := range possibleValues.([]interface{}) {
+			labels = append(labels, label.(string))
+		}
+
+		labelFilters = append(labelFilters, labels)
+	}
+
+	providers := []interface{}{}
+	for _, provider := range query.TerraformProviders {
+		if matchesLabels(provider.Labels, labelFilters) {
+			providers = append(providers, flattenTerraformProvider(provider))
+		}
+	}
+
+	d.SetId("spacelift-terraform-providers")
+
+	if err := d.Set("providers", providers); err != nil {
+		return diag.Errorf("could not set Terraform providers: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package spacelift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestTerraformProvidersData(t *testing.T) {
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlpha)
+
+	testSteps(t, []resource.TestStep{{
+		Config: fmt.Sprintf(`
+			resource "spacelift_terraform_provider" "test" {
+				type     = "%s"
+				space_id = "root"
+				labels   = ["%s"]
+			}
+
+			data "spacelift_terraform_providers" "test" {
+				depends_on = [spacelift_terraform_provider.test]
+
+				labels {
+				  any_of = ["%s"]
+				}
+			}
+		`, randomID, randomID, randomID),
+		Check: Resource(
+			"data.spacelift_terraform_providers.test",
+			Attribute("id", Equals("spacelift-terraform-providers")),
+			Attribute("providers.#", Equals("1")),
+			Attribute("providers.0.type", Equals(randomID)),
+			Attribute("providers.0.space_id", Equals("root")),
+		),
+	}})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -22,6 +23,8 @@ type Client struct {
 	limiter           *rate.Limiter
 	requestsPerSecond *int
 	maxBurst          *int
+
+	subscriptionsUnavailable atomic.Bool
 }
 
 // NewClient returns a new Spacelift client for the specified endpoint, token and limiter.
@@ -56,7 +59,12 @@ func (c *Client) Query(ctx context.Context, queryName string, q interface{}, var
 }
 
 func (c *Client) client(ctx context.Context) *graphql.Client {
-	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token}))
+	client := &http.Client{
+		Transport: &oauth2.Transport{
+			Base:   c.transport(ctx),
+			Source: oauth2.ReuseTokenSource(nil, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token})),
+		},
+	}
 
 	if c.limiter != nil {
 		client = &http.Client{
@@ -79,20 +87,44 @@ func (c *Client) client(ctx context.Context) *graphql.Client {
 	)
 }
 
+// transport returns the transport all the HTTP requests of the client go
+// through: the one of the HTTP client set in the context under
+// oauth2.HTTPClient, if any, or the default one, which honours the proxy
+// environment variables.
+func (c *Client) transport(ctx context.Context) http.RoundTripper {
+	if client, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && client != nil && client.Transport != nil {
+		return client.Transport
+	}
+
+	return http.DefaultTransport
+}
+
 func (c *Client) url() string {
 	return fmt.Sprintf("%s/graphql", c.Endpoint)
 }
 
 func (c *Client) getRequestOptions() []graphql.RequestOption {
-	options := []graphql.RequestOption{
-		graphql.WithHeader("Spacelift-Client-Type", "provider"),
-		graphql.WithHeader("Spacelift-Provider-Commit", c.Commit),
-		graphql.WithHeader("Spacelift-Provider-Version", c.Version)}
+	headers := c.getHeaders()
 
-	if c.requestsPerSecond != nil && c.maxBurst != nil {
-		options = append(options, graphql.WithHeader("Spacelift-Provider-Max-RPS", fmt.Sprint(*c.requestsPerSecond)))
-		options = append(options, graphql.WithHeader("Spacelift-Provider-Max-Request-Burst", fmt.Sprint(*c.maxBurst)))
+	options := make([]graphql.RequestOption, 0, len(headers))
+	for name, value := range headers {
+		options = append(options, graphql.WithHeader(name, value))
 	}
 
 	return options
 }
+
+func (c *Client) getHeaders() map[string]string {
+	headers := map[string]string{
+		"Spacelift-Client-Type":      "provider",
+		"Spacelift-Provider-Commit":  c.Commit,
+		"Spacelift-Provider-Version": c.Version,
+	}
+
+	if c.requestsPerSecond != nil && c.maxBurst != nil {
+		headers["Spacelift-Provider-Max-RPS"] = fmt.Sprint(*c.requestsPerSecond)
+		headers["Spacelift-Provider-Max-Request-Burst"] = fmt.Sprint(*c.maxBurst)
+	}
+
+	return headers
+}
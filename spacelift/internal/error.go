@@ -3,6 +3,8 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/shurcooL/graphql"
@@ -47,6 +49,41 @@ func parseExtensions(ext map[string]interface{}) string {
 	return strings.Join(errorParts, ", ")
 }
 
+// conflictErrorCodes are the codes in the extensions of a GraphQL error which
+// mean that the entity being created already exists.
+var conflictErrorCodes = []string{"ALREADY_EXISTS", "CONFLICT"}
+
+// conflictErrorPattern matches the error messages saying something is taken.
+var conflictErrorPattern = regexp.MustCompile(`(?i)already (exists|taken|in use)|not unique`)
+
+// IsConflictError reports whether the GraphQL error means that the entity with
+// the given ID being created already exists. Errors with a code in their
+// extensions are judged by it alone. Otherwise, the message must mention the ID
+// itself, so that a conflict on any other field (e.g. a label) doesn't count.
+func IsConflictError(err error, id string) bool {
+	graphErrs, ok := AsError[graphql.GraphQLErrors](err)
+	if !ok || id == "" {
+		return false
+	}
+
+	idPattern := regexp.MustCompile(`(^|[^\w-])` + regexp.QuoteMeta(id) + `($|[^\w-])`)
+
+	for _, graphErr := range graphErrs {
+		if code, ok := graphErr.Extensions["code"].(string); ok {
+			if slices.Contains(conflictErrorCodes, strings.ToUpper(code)) {
+				return true
+			}
+			continue
+		}
+
+		if conflictErrorPattern.MatchString(graphErr.Message) && idPattern.MatchString(graphErr.Message) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // AsError is an inline form of errors.As.
 func AsError[TError error](err error) (TError, bool) {
 	var as TError
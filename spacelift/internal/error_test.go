@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shurcooL/graphql"
+)
+
+func graphQLError(message string, extensions map[string]interface{}) error {
+	return graphql.GraphQLErrors{{Message: message, Extensions: extensions}}
+}
+
+func TestIsConflictError(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		err      error
+		id       string
+		conflict bool
+	}{
+		{"no error", nil, "my-context", false},
+		{"not a GraphQL error", errors.New("context with slug my-context already exists"), "my-context", false},
+		{"conflict code", graphQLError("could not create context", map[string]interface{}{"code": "ALREADY_EXISTS"}), "my-context", true},
+		{"other code", graphQLError("my-context already exists", map[string]interface{}{"code": "BAD_REQUEST"}), "my-context", false},
+		{"message with the ID", graphQLError("context with slug my-context already exists", nil), "my-context", true},
+		{"message with the quoted ID", graphQLError(`Stack ID "my-stack" is already taken`, nil), "my-stack", true},
+		{"message about another field", graphQLError("label prod is not unique", nil), "my-context", false},
+		{"message with a longer ID", graphQLError("context with slug my-context-2 already exists", nil), "my-context", false},
+		{"message without conflict", graphQLError("my-context: unauthorized", nil), "my-context", false},
+		{"unknown ID", graphQLError("context with slug my-context already exists", nil), "", false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if got := IsConflictError(testCase.err, testCase.id); got != testCase.conflict {
+				t.Errorf("IsConflictError(%v, %q) = %t, want %t", testCase.err, testCase.id, got, testCase.conflict)
+			}
+		})
+	}
+}
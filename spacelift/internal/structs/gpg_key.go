@@ -0,0 +1,11 @@
+package structs
+
+// GPGKey represents a GPG key registered for signing Terraform provider
+// versions.
+type GPGKey struct {
+	ID          string  `graphql:"id"`
+	Name        string  `graphql:"name"`
+	Description *string `graphql:"description"`
+	CreatedAt   int     `graphql:"createdAt"`
+	RevokedAt   *int    `graphql:"revokedAt"`
+}
@@ -35,6 +35,18 @@ type Module struct {
 
 // ExportVCSSettings exports VCS settings into Terraform schema.
 func (m *Module) ExportVCSSettings(d *schema.ResourceData) error {
+	if fieldName, vcsSettings := m.VCSSettings(); fieldName != "" {
+		if err := d.Set(fieldName, []interface{}{vcsSettings}); err != nil {
+			return errors.Wrapf(err, "error setting %s (resource)", fieldName)
+		}
+	}
+
+	return nil
+}
+
+// VCSSettings returns the name of the Terraform attribute holding the VCS
+// settings of the module and their values, if any.
+func (m *Module) VCSSettings() (string, map[string]interface{}) {
 	var fieldName string
 	var vcsSettings map[string]interface{}
 
@@ -86,11 +98,5 @@ func (m *Module) ExportVCSSettings(d *schema.ResourceData) error {
 		fieldName = "gitlab"
 	}
 
-	if fieldName != "" {
-		if err := d.Set(fieldName, []interface{}{vcsSettings}); err != nil {
-			return errors.Wrapf(err, "error setting %s (resource)", fieldName)
-		}
-	}
-
-	return nil
+	return fieldName, vcsSettings
 }
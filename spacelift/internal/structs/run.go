@@ -0,0 +1,69 @@
+package structs
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// RunState represents a run state.
+type RunState string
+
+// RunType represents a run type.
+type RunType string
+
+// Run represents Run data relevant to the provider.
+type Run struct {
+	ID     string `graphql:"id"`
+	Commit struct {
+		Hash string `graphql:"hash"`
+	} `graphql:"commit"`
+	CreatedAt int `graphql:"createdAt"`
+	Delta     *struct {
+		AddCount    int `graphql:"addCount"`
+		ChangeCount int `graphql:"changeCount"`
+		DeleteCount int `graphql:"deleteCount"`
+	} `graphql:"delta"`
+	Finished bool `graphql:"finished"`
+	History  []struct {
+		State     string `graphql:"state"`
+		Timestamp int    `graphql:"timestamp"`
+	} `graphql:"history"`
+	State       string  `graphql:"state"`
+	TriggeredBy *string `graphql:"triggeredBy"`
+	Type        string  `graphql:"type"`
+}
+
+// PopulateResourceData populates Terraform resource data with the contents of
+// the Run.
+func (r *Run) PopulateResourceData(d *schema.ResourceData) {
+	d.Set("state", strings.ToLower(r.State))
+	d.Set("finished", r.Finished)
+	d.Set("commit", r.Commit.Hash)
+	d.Set("created_at", r.CreatedAt)
+	d.Set("proposed", r.Type == "PROPOSED")
+
+	if r.TriggeredBy != nil {
+		d.Set("triggered_by", *r.TriggeredBy)
+	} else {
+		d.Set("triggered_by", nil)
+	}
+
+	// History is ordered from the most recent state to the oldest one, so the
+	// first entry of a finished run is the transition to its terminal state.
+	if r.Finished && len(r.History) > 0 {
+		d.Set("finished_at", r.History[0].Timestamp)
+	} else {
+		d.Set("finished_at", nil)
+	}
+
+	if r.Delta != nil {
+		d.Set("resources_added", r.Delta.AddCount)
+		d.Set("resources_changed", r.Delta.ChangeCount)
+		d.Set("resources_deleted", r.Delta.DeleteCount)
+	} else {
+		d.Set("resources_added", nil)
+		d.Set("resources_changed", nil)
+		d.Set("resources_deleted", nil)
+	}
+}
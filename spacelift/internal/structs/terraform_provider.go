@@ -1,5 +1,7 @@
 package structs
 
+import "github.com/shurcooL/graphql"
+
 // TerraformProvider represents the data about a Terraform provider.
 type TerraformProvider struct {
 	ID          string   `graphql:"id"`
@@ -8,3 +10,28 @@ type TerraformProvider struct {
 	Public      bool     `graphql:"public"`
 	Space       string   `graphql:"space"`
 }
+
+// TerraformProviderVersion represents the data about a version of a Terraform
+// provider.
+type TerraformProviderVersion struct {
+	ID        string   `graphql:"id"`
+	Number    string   `graphql:"number"`
+	Protocols []string `graphql:"protocols"`
+	Status    string   `graphql:"status"`
+}
+
+// TerraformProviderVersionInput represents the input for creating a version of
+// a Terraform provider.
+type TerraformProviderVersionInput struct {
+	GPGKeyID  graphql.ID       `json:"gpgKeyID"`
+	Protocols []graphql.String `json:"protocols"`
+	Version   graphql.String   `json:"version"`
+}
+
+// TerraformProviderVersionPlatformInput represents the input for registering a
+// platform binary of a Terraform provider version.
+type TerraformProviderVersionPlatformInput struct {
+	Architecture graphql.String `json:"architecture"`
+	BinarySHA256 graphql.String `json:"binarySHA256"`
+	OS           graphql.String `json:"os"`
+}
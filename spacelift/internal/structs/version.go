@@ -0,0 +1,40 @@
+package structs
+
+// Version represents a module version.
+type Version struct {
+	ID        string  `graphql:"id"`
+	Number    string  `graphql:"number"`
+	State     string  `graphql:"state"`
+	Yanked    bool    `graphql:"yanked"`
+	YankNote  *string `graphql:"yankNote"`
+	CreatedAt int     `graphql:"createdAt"`
+	Commit    struct {
+		Hash    string `graphql:"hash"`
+		Message string `graphql:"message"`
+	} `graphql:"commit"`
+	Metadata *VersionMetadata `graphql:"metadata"`
+}
+
+// VersionMetadata represents the interface of a module version, as parsed
+// from its source code.
+type VersionMetadata struct {
+	Root struct {
+		Inputs []struct {
+			Name        string  `graphql:"name"`
+			Type        string  `graphql:"type"`
+			Description string  `graphql:"description"`
+			Default     *string `graphql:"default"`
+			Required    bool    `graphql:"required"`
+		} `graphql:"inputs"`
+		Outputs []struct {
+			Name        string `graphql:"name"`
+			Description string `graphql:"description"`
+		} `graphql:"outputs"`
+		ProviderDependencies []struct {
+			Name      string `graphql:"name"`
+			Namespace string `graphql:"namespace"`
+			Source    string `graphql:"source"`
+			Version   string `graphql:"version"`
+		} `graphql:"providerDependencies"`
+	} `graphql:"root"`
+}
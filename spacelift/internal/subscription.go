@@ -0,0 +1,233 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shurcooL/graphql"
+	"golang.org/x/net/websocket"
+)
+
+// subscriptionProtocol is the websocket subprotocol used for GraphQL
+// subscriptions.
+const subscriptionProtocol = "graphql-transport-ws"
+
+// subscriptionHandshakeTimeout bounds the websocket handshake if the context
+// doesn't have an earlier deadline.
+const subscriptionHandshakeTimeout = 30 * time.Second
+
+// ErrSubscriptionsUnavailable is returned by Subscribe if the server does not
+// support GraphQL subscriptions, in which case the caller should fall back to
+// polling. Once the server has refused a subscription, the client won't try
+// again.
+var ErrSubscriptionsUnavailable = errors.New("GraphQL subscriptions are not available")
+
+// subscriptionMessage is a message of the graphql-transport-ws protocol.
+type subscriptionMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Subscribe runs a GraphQL subscription over a websocket, and calls handle
+// with the data of every event. It returns once the context is cancelled,
+// handle returns an error or the server completes the subscription.
+func (c *Client) Subscribe(ctx context.Context, subscriptionName, query string, variables map[string]interface{}, handle func(data json.RawMessage) error) error {
+	if c.subscriptionsUnavailable.Load() {
+		return ErrSubscriptionsUnavailable
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	conn, err := c.dialSubscription(ctx, subscriptionName)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Reads on the websocket don't take a context, so closing the connection
+	// is the only way to interrupt them.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	err = c.subscribe(conn, subscriptionName, query, variables, handle)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	return err
+}
+
+func (c *Client) dialSubscription(ctx context.Context, subscriptionName string) (*websocket.Conn, error) {
+	location, err := url.Parse(c.url())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid endpoint")
+	}
+
+	origin := *location
+	switch location.Scheme {
+	case "https":
+		location.Scheme = "wss"
+	case "http":
+		location.Scheme = "ws"
+	default:
+		return nil, errors.Errorf("unsupported endpoint scheme %q", location.Scheme)
+	}
+
+	config, err := websocket.NewConfig(location.String(), origin.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not configure websocket")
+	}
+	config.Protocol = []string{subscriptionProtocol}
+	config.Header.Set("Authorization", "Bearer "+c.Token)
+	config.Header.Set("Spacelift-GraphQL-Subscription", subscriptionName)
+	for name, value := range c.getHeaders() {
+		config.Header.Set(name, value)
+	}
+
+	address := location.Host
+	if location.Port() == "" {
+		port := "80"
+		if location.Scheme == "wss" {
+			port = "443"
+		}
+		address = net.JoinHostPort(location.Hostname(), port)
+	}
+
+	var netConn net.Conn
+	if location.Scheme == "wss" {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: location.Hostname(), MinVersion: tls.VersionTLS12}}
+		netConn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		netConn, err = (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to websocket")
+	}
+
+	// The handshake doesn't take a context either, so let's bound it with a
+	// deadline and close the connection if the context is cancelled meanwhile.
+	deadline := time.Now().Add(subscriptionHandshakeTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := netConn.SetDeadline(deadline); err != nil {
+		netConn.Close()
+		return nil, errors.Wrap(err, "could not open websocket")
+	}
+
+	stop := context.AfterFunc(ctx, func() { netConn.Close() })
+	conn, err := websocket.NewClient(config, netConn)
+	stop()
+
+	if err != nil {
+		netConn.Close()
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		// The server doesn't accept websockets, or not for this protocol.
+		if IsErrorType[*websocket.ProtocolError](err) {
+			c.subscriptionsUnavailable.Store(true)
+			return nil, ErrSubscriptionsUnavailable
+		}
+
+		return nil, errors.Wrap(err, "could not open websocket")
+	}
+
+	if err := netConn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "could not open websocket")
+	}
+
+	return conn, nil
+}
+
+func (c *Client) subscribe(conn *websocket.Conn, subscriptionName, query string, variables map[string]interface{}, handle func(data json.RawMessage) error) error {
+	initPayload, err := json.Marshal(map[string]string{"Authorization": "Bearer " + c.Token})
+	if err != nil {
+		return err
+	}
+
+	if err := websocket.JSON.Send(conn, subscriptionMessage{Type: "connection_init", Payload: initPayload}); err != nil {
+		return errors.Wrap(err, "could not initialize subscription")
+	}
+
+	var ack subscriptionMessage
+	if err := websocket.JSON.Receive(conn, &ack); err != nil {
+		return errors.Wrap(err, "could not initialize subscription")
+	}
+	if ack.Type != "connection_ack" {
+		c.subscriptionsUnavailable.Store(true)
+		return ErrSubscriptionsUnavailable
+	}
+
+	subscribePayload, err := json.Marshal(map[string]interface{}{
+		"operationName": subscriptionName,
+		"query":         query,
+		"variables":     variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	const id = "1"
+
+	if err := websocket.JSON.Send(conn, subscriptionMessage{ID: id, Type: "subscribe", Payload: subscribePayload}); err != nil {
+		return errors.Wrap(err, "could not subscribe")
+	}
+
+	for {
+		var message subscriptionMessage
+		if err := websocket.JSON.Receive(conn, &message); err != nil {
+			return errors.Wrap(err, "could not read subscription event")
+		}
+
+		switch message.Type {
+		case "ping":
+			if err := websocket.JSON.Send(conn, subscriptionMessage{Type: "pong"}); err != nil {
+				return errors.Wrap(err, "could not answer ping")
+			}
+		case "next":
+			if message.ID != id {
+				continue
+			}
+
+			var payload struct {
+				Data   json.RawMessage       `json:"data"`
+				Errors graphql.GraphQLErrors `json:"errors"`
+			}
+			if err := json.Unmarshal(message.Payload, &payload); err != nil {
+				return errors.Wrap(err, "could not decode subscription event")
+			}
+			if len(payload.Errors) > 0 {
+				return payload.Errors
+			}
+
+			if err := handle(payload.Data); err != nil {
+				// Let the server know we're done, but it's not a big deal if
+				// it doesn't get the message.
+				_ = websocket.JSON.Send(conn, subscriptionMessage{ID: id, Type: "complete"})
+				return err
+			}
+		case "error":
+			var errs graphql.GraphQLErrors
+			if err := json.Unmarshal(message.Payload, &errs); err != nil {
+				return errors.Wrap(err, "could not decode subscription error")
+			}
+			return errs
+		case "complete":
+			return nil
+		}
+	}
+}
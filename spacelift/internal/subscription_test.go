@@ -0,0 +1,191 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// subscriptionServer is a local stand-in for the Spacelift websocket endpoint,
+// which acknowledges the connection, reads the subscription and hands the
+// connection over to serve.
+func subscriptionServer(t *testing.T, serve func(ws *websocket.Conn, subscribe map[string]interface{})) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(websocket.Server{
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			if r.URL.Path != "/graphql" {
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+			if got := r.Header.Get("Authorization"); got != "Bearer token" {
+				t.Errorf("unexpected authorization header %q", got)
+			}
+			config.Protocol = []string{subscriptionProtocol}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			var message subscriptionMessage
+			if err := websocket.JSON.Receive(ws, &message); err != nil || message.Type != "connection_init" {
+				t.Errorf("expected connection_init, got %+v (%v)", message, err)
+				return
+			}
+			if err := websocket.JSON.Send(ws, subscriptionMessage{Type: "connection_ack"}); err != nil {
+				t.Errorf("could not send connection_ack: %v", err)
+				return
+			}
+
+			if err := websocket.JSON.Receive(ws, &message); err != nil || message.Type != "subscribe" {
+				t.Errorf("expected subscribe, got %+v (%v)", message, err)
+				return
+			}
+
+			var payload map[string]interface{}
+			if err := json.Unmarshal(message.Payload, &payload); err != nil {
+				t.Errorf("could not decode subscribe payload: %v", err)
+				return
+			}
+
+			serve(ws, payload)
+		},
+	})
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func send(t *testing.T, ws *websocket.Conn, messageType, payload string) {
+	t.Helper()
+
+	message := subscriptionMessage{ID: "1", Type: messageType}
+	if payload != "" {
+		message.Payload = json.RawMessage(payload)
+	}
+
+	if err := websocket.JSON.Send(ws, message); err != nil {
+		t.Errorf("could not send %s: %v", messageType, err)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	server := subscriptionServer(t, func(ws *websocket.Conn, subscribe map[string]interface{}) {
+		if subscribe["operationName"] != "RunStateChanged" {
+			t.Errorf("unexpected operation %v", subscribe["operationName"])
+		}
+		if variables := subscribe["variables"].(map[string]interface{}); variables["runId"] != "run" {
+			t.Errorf("unexpected variables %v", variables)
+		}
+
+		websocket.JSON.Send(ws, subscriptionMessage{Type: "ping"})
+		send(t, ws, "next", `{"data":{"state":"PLANNING"}}`)
+		send(t, ws, "next", `{"data":{"state":"FINISHED"}}`)
+		send(t, ws, "complete", "")
+	})
+
+	client := NewClient(server.URL, "token", nil, nil)
+
+	var events []string
+	err := client.Subscribe(context.Background(), "RunStateChanged", "subscription { state }", map[string]interface{}{"runId": "run"}, func(data json.RawMessage) error {
+		events = append(events, string(data))
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(events, ","); got != `{"state":"PLANNING"},{"state":"FINISHED"}` {
+		t.Fatalf("unexpected events %s", got)
+	}
+}
+
+func TestSubscribeErrors(t *testing.T) {
+	server := subscriptionServer(t, func(ws *websocket.Conn, _ map[string]interface{}) {
+		send(t, ws, "error", `[{"message": "run not found"}]`)
+	})
+
+	client := NewClient(server.URL, "token", nil, nil)
+
+	err := client.Subscribe(context.Background(), "RunStateChanged", "subscription { state }", nil, func(json.RawMessage) error {
+		t.Error("unexpected event")
+		return nil
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "run not found") {
+		t.Fatalf("expected subscription error, got %v", err)
+	}
+}
+
+func TestSubscribeCancelled(t *testing.T) {
+	server := subscriptionServer(t, func(ws *websocket.Conn, _ map[string]interface{}) {
+		// Keep the subscription open until the client goes away.
+		var message subscriptionMessage
+		websocket.JSON.Receive(ws, &message)
+	})
+
+	client := NewClient(server.URL, "token", nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.Subscribe(ctx, "RunStateChanged", "subscription { state }", nil, func(json.RawMessage) error {
+		return nil
+	})
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestSubscribeHandshakeCancelled(t *testing.T) {
+	// Accept the connection but never answer the handshake.
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	client := NewClient(server.URL, "token", nil, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.Subscribe(ctx, "RunStateChanged", "subscription { state }", nil, func(json.RawMessage) error {
+		t.Error("unexpected event")
+		return nil
+	})
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestSubscribeUnavailable(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token", nil, nil)
+
+	for i := 0; i < 2; i++ {
+		err := client.Subscribe(context.Background(), "RunStateChanged", "subscription { state }", nil, func(json.RawMessage) error {
+			return nil
+		})
+		if err != ErrSubscriptionsUnavailable {
+			t.Fatalf("expected ErrSubscriptionsUnavailable, got %v", err)
+		}
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected a single attempt to subscribe, got %d", got)
+	}
+}
@@ -0,0 +1,207 @@
+// Package tfstate contains helpers to inspect Terraform state files without
+// loading them into memory in full.
+package tfstate
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// MaxSize is the maximum size of an uncompressed state file.
+const MaxSize = 512 << 20
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Metadata represents the top-level attributes identifying a state file.
+type Metadata struct {
+	Version int64
+	Lineage string
+	Serial  int64
+}
+
+// Decompress returns a reader of the uncompressed content of r, which may be
+// plain, gzip-compressed or zstd-compressed. Closing the returned reader also
+// closes r.
+func Decompress(r io.ReadCloser) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+
+	header, err := buffered.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		r.Close()
+		return nil, errors.Wrap(err, "could not read state header")
+	}
+
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		reader, err := gzip.NewReader(buffered)
+		if err != nil {
+			r.Close()
+			return nil, errors.Wrap(err, "could not read gzip-compressed state")
+		}
+		return &readCloser{Reader: reader, closers: []io.Closer{reader, r}}, nil
+	case bytes.HasPrefix(header, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			r.Close()
+			return nil, errors.Wrap(err, "could not read zstd-compressed state")
+		}
+		return &readCloser{Reader: decoder, closers: []io.Closer{decoder.IOReadCloser(), r}}, nil
+	default:
+		return &readCloser{Reader: buffered, closers: []io.Closer{r}}, nil
+	}
+}
+
+// Inspect streams the uncompressed state from r, checking that it's a single
+// JSON object no larger than MaxSize with valid version, lineage and serial
+// attributes. It returns the metadata and the size of the state in bytes.
+func Inspect(r io.Reader) (*Metadata, int64, error) {
+	counter := &countingReader{reader: io.LimitReader(r, MaxSize+1)}
+
+	decoder := json.NewDecoder(counter)
+	decoder.UseNumber()
+
+	metadata, err := inspect(decoder)
+	if counter.count > MaxSize {
+		return nil, 0, errors.Errorf("state is larger than %d bytes", MaxSize)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return metadata, counter.count, nil
+}
+
+func inspect(decoder *json.Decoder) (*Metadata, error) {
+	if token, err := decoder.Token(); err != nil {
+		return nil, errors.Wrap(err, "state is not valid JSON")
+	} else if token != json.Delim('{') {
+		return nil, errors.New("state is not a JSON object")
+	}
+
+	var metadata Metadata
+	var hasVersion, hasLineage, hasSerial bool
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, errors.Wrap(err, "state is not valid JSON")
+		}
+
+		switch token.(string) {
+		case "version":
+			if metadata.Version, err = decodeInt(decoder, "version"); err != nil {
+				return nil, err
+			}
+			hasVersion = true
+		case "serial":
+			if metadata.Serial, err = decodeInt(decoder, "serial"); err != nil {
+				return nil, err
+			}
+			hasSerial = true
+		case "lineage":
+			if err := decoder.Decode(&metadata.Lineage); err != nil {
+				return nil, errors.Wrap(err, `"lineage" must be a string`)
+			}
+			hasLineage = true
+		default:
+			if err := skipValue(decoder); err != nil {
+				return nil, errors.Wrap(err, "state is not valid JSON")
+			}
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, errors.Wrap(err, "state is not valid JSON")
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("state must contain a single JSON object")
+	}
+
+	switch {
+	case !hasVersion:
+		return nil, errors.New(`state is missing the "version" attribute`)
+	case metadata.Version < 3 || metadata.Version > 4:
+		return nil, errors.Errorf("unsupported state version %d", metadata.Version)
+	case !hasLineage || metadata.Lineage == "":
+		return nil, errors.New(`state is missing the "lineage" attribute`)
+	case !hasSerial:
+		return nil, errors.New(`state is missing the "serial" attribute`)
+	case metadata.Serial < 0:
+		return nil, errors.New(`"serial" must not be negative`)
+	}
+
+	return &metadata, nil
+}
+
+func decodeInt(decoder *json.Decoder, name string) (int64, error) {
+	var number json.Number
+	if err := decoder.Decode(&number); err != nil {
+		return 0, errors.Wrapf(err, "%q must be a number", name)
+	}
+
+	value, err := number.Int64()
+	if err != nil {
+		return 0, errors.Errorf("%q must be an integer", name)
+	}
+
+	return value, nil
+}
+
+// skipValue consumes the next value without keeping it in memory.
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *readCloser) Close() error {
+	var firstErr error
+	for _, closer := range r.closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
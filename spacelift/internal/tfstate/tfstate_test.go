@@ -0,0 +1,84 @@
+package tfstate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const validState = `{"version": 4, "terraform_version": "1.5.7", "serial": 7, "lineage": "abc", "outputs": {"x": {"value": [1, {"a": "b"}]}}, "resources": []}`
+
+func TestInspect(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "valid", content: validState},
+		{name: "not JSON", content: `version = 4`, err: "state is not valid JSON"},
+		{name: "not an object", content: `[]`, err: "state is not a JSON object"},
+		{name: "trailing content", content: validState + `{}`, err: "state must contain a single JSON object"},
+		{name: "missing version", content: `{"serial": 1, "lineage": "abc"}`, err: `state is missing the "version" attribute`},
+		{name: "unsupported version", content: `{"version": 2, "serial": 1, "lineage": "abc"}`, err: "unsupported state version 2"},
+		{name: "missing lineage", content: `{"version": 4, "serial": 1}`, err: `state is missing the "lineage" attribute`},
+		{name: "missing serial", content: `{"version": 4, "lineage": "abc"}`, err: `state is missing the "serial" attribute`},
+		{name: "fractional serial", content: `{"version": 4, "serial": 1.5, "lineage": "abc"}`, err: `"serial" must be an integer`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, size, err := Inspect(strings.NewReader(tc.content))
+
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *metadata != (Metadata{Version: 4, Lineage: "abc", Serial: 7}) {
+				t.Errorf("unexpected metadata: %+v", metadata)
+			}
+			if size != int64(len(tc.content)) {
+				t.Errorf("expected size %d, got %d", len(tc.content), size)
+			}
+		})
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	var gzipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipped)
+	gzipWriter.Write([]byte(validState))
+	gzipWriter.Close()
+
+	zstdEncoder, _ := zstd.NewWriter(nil)
+	zstded := zstdEncoder.EncodeAll([]byte(validState), nil)
+
+	for name, content := range map[string][]byte{
+		"plain": []byte(validState),
+		"gzip":  gzipped.Bytes(),
+		"zstd":  zstded,
+	} {
+		t.Run(name, func(t *testing.T) {
+			reader, err := Decompress(io.NopCloser(bytes.NewReader(content)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer reader.Close()
+
+			decompressed, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(decompressed) != validState {
+				t.Errorf("unexpected content: %s", decompressed)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/pkg/errors"
+)
+
+// Upload PUTs the content returned by body to a presigned URL. The body is
+// requested anew for every attempt, so that retries don't need to buffer it.
+func (c *Client) Upload(ctx context.Context, url string, body retryablehttp.ReaderFunc, contentLength int64, contentType string) error {
+	request, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPut, url, body)
+	if err != nil {
+		return errors.Wrap(err, "could not create upload request")
+	}
+	request.ContentLength = contentLength
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := c.transferClient(ctx).Do(request)
+	if err != nil {
+		return errors.Wrap(err, "could not upload to remote URL")
+	}
+	defer response.Body.Close()
+
+	if (response.StatusCode / 100) != 2 {
+		return errors.Errorf("unexpected HTTP status code when uploading: %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+// Download GETs the content of a presigned URL. The caller is responsible for
+// closing the returned reader.
+func (c *Client) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	request, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create download request")
+	}
+
+	response, err := c.transferClient(ctx).Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not download from remote URL")
+	}
+
+	if (response.StatusCode / 100) != 2 {
+		response.Body.Close()
+		return nil, errors.Errorf("unexpected HTTP status code when downloading: %d", response.StatusCode)
+	}
+
+	return response.Body, nil
+}
+
+// transferClient returns a client for transfers to and from presigned URLs.
+// It shares the transport, rate limiting and retry settings of the API client,
+// but does not send the API token, since presigned URLs carry their own
+// credentials.
+func (c *Client) transferClient(ctx context.Context) *retryablehttp.Client {
+	client := &http.Client{Transport: c.transport(ctx)}
+
+	if c.limiter != nil {
+		client = &http.Client{
+			Transport: newRateLimitingRoundTripper(client, c.limiter),
+		}
+	}
+
+	retryableClient := retryablehttp.NewClient()
+	retryableClient.HTTPClient = client
+	retryableClient.Logger = nil
+
+	return retryableClient
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// countingTransport counts the requests going through it.
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestDownloadUsesClientTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("unexpected authorization header %q", got)
+		}
+		io.WriteString(w, "state")
+	}))
+	t.Cleanup(server.Close)
+
+	transport := &countingTransport{}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: transport})
+
+	client := NewClient(server.URL, "token", nil, nil)
+
+	body, err := client.Download(ctx, server.URL+"/state.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer body.Close()
+
+	if content, _ := io.ReadAll(body); string(content) != "state" {
+		t.Fatalf("unexpected content %q", content)
+	}
+	if got := transport.requests.Load(); got != 1 {
+		t.Fatalf("expected the download to go through the client transport once, got %d requests", got)
+	}
+}
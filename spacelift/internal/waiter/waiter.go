@@ -0,0 +1,233 @@
+// Package waiter implements polling for long-running operations, like runs,
+// module versions and stack destructions, until they reach a final state.
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMinInterval = 5 * time.Second
+	defaultMaxInterval = time.Minute
+	defaultMultiplier  = 1.5
+	defaultJitter      = 0.2
+)
+
+// RefreshFunc returns the current state of the awaited object, and whether
+// that state is terminal, i.e. won't change anymore.
+type RefreshFunc func(ctx context.Context) (state string, terminal bool, err error)
+
+// Update is a state change of the awaited object.
+type Update struct {
+	State    string
+	Terminal bool
+}
+
+// WatchFunc streams state changes of the awaited object to updates until the
+// context is cancelled. It returns an error if it can't watch the object (any
+// longer), in which case the waiter falls back to polling.
+type WatchFunc func(ctx context.Context, updates chan<- Update) error
+
+// Waiter polls an object until it reaches an accepted or terminal state.
+type Waiter struct {
+	// Name describes the awaited object in logs and errors.
+	Name string
+
+	// Refresh returns the current state of the awaited object.
+	Refresh RefreshFunc
+
+	// Watch, if set, streams state changes of the awaited object. While it
+	// works, Refresh is only called once at the start and every MaxInterval
+	// afterwards, to catch up on anything the stream may have missed.
+	Watch WatchFunc
+
+	// Accepted are the states in which waiting ends successfully. If empty,
+	// waiting ends successfully in any terminal state.
+	Accepted []string
+
+	// Terminal are the states in which waiting ends, on top of the ones
+	// reported as terminal by Refresh.
+	Terminal []string
+
+	// Timeout is the maximum time to wait for. Zero means no timeout other
+	// than the one of the context.
+	Timeout time.Duration
+
+	// Delay is the time to wait before the first refresh.
+	Delay time.Duration
+
+	// MinInterval and MaxInterval bound the time between refreshes, which
+	// grows exponentially by Multiplier and is randomized by a Jitter factor.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	Multiplier  float64
+	Jitter      float64
+}
+
+// TimeoutError is returned if the object didn't reach an accepted or
+// terminal state in time.
+type TimeoutError struct {
+	Name      string
+	LastState string
+	Timeout   time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout while waiting for %s after %s (last state: %s)", e.Name, e.Timeout, e.LastState)
+}
+
+// UnexpectedStateError is returned if the object reached a terminal state
+// which is not accepted.
+type UnexpectedStateError struct {
+	Name     string
+	State    string
+	Expected []string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("%s ended in state %s, expected %s", e.Name, e.State, strings.Join(e.Expected, ", "))
+}
+
+// Wait polls the object until it reaches an accepted or terminal state, and
+// returns it. If the context is cancelled, its error is returned as is.
+func (w *Waiter) Wait(ctx context.Context) (string, error) {
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+
+	var updates chan Update
+	var watchErrs chan error
+
+	if w.Watch != nil {
+		watchCtx, stopWatching := context.WithCancel(ctx)
+		defer stopWatching()
+
+		updates = make(chan Update)
+		watchErrs = make(chan error, 1)
+		go func() { watchErrs <- w.Watch(watchCtx, updates) }()
+	}
+
+	start := time.Now()
+	interval := w.minInterval()
+	delay := w.Delay
+	lastState := ""
+
+	for attempt := 1; ; attempt++ {
+		var state, source string
+		var terminal bool
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return lastState, w.contextError(ctx, lastState)
+		case err := <-watchErrs:
+			timer.Stop()
+			tflog.Info(ctx, "waiting: falling back to polling", map[string]interface{}{
+				"name":  w.Name,
+				"error": fmt.Sprint(err),
+			})
+			updates, watchErrs = nil, nil
+			delay = 0
+			continue
+		case update := <-updates:
+			timer.Stop()
+			state, terminal, source = update.State, update.Terminal, "watch"
+		case <-timer.C:
+			var err error
+			if state, terminal, err = w.Refresh(ctx); err != nil {
+				if ctx.Err() != nil {
+					return lastState, w.contextError(ctx, lastState)
+				}
+				return lastState, err
+			}
+			source = "refresh"
+		}
+
+		fields := map[string]interface{}{
+			"name":    w.Name,
+			"state":   state,
+			"source":  source,
+			"attempt": attempt,
+			"elapsed": time.Since(start).Round(time.Second).String(),
+		}
+		if state != lastState {
+			tflog.Info(ctx, "waiting: state changed", fields)
+		} else {
+			tflog.Debug(ctx, "waiting: state unchanged", fields)
+		}
+		lastState = state
+
+		if slices.Contains(w.Accepted, state) {
+			return state, nil
+		}
+
+		if terminal || slices.Contains(w.Terminal, state) {
+			if len(w.Accepted) > 0 {
+				return state, &UnexpectedStateError{Name: w.Name, State: state, Expected: w.Accepted}
+			}
+			return state, nil
+		}
+
+		if updates != nil {
+			delay = w.jitter(w.maxInterval())
+			continue
+		}
+
+		delay = w.jitter(interval)
+		interval = w.nextInterval(interval)
+	}
+}
+
+func (w *Waiter) contextError(ctx context.Context, lastState string) error {
+	if ctx.Err() == context.DeadlineExceeded && w.Timeout > 0 {
+		return &TimeoutError{Name: w.Name, LastState: lastState, Timeout: w.Timeout}
+	}
+	return ctx.Err()
+}
+
+func (w *Waiter) minInterval() time.Duration {
+	if w.MinInterval > 0 {
+		return w.MinInterval
+	}
+	return defaultMinInterval
+}
+
+func (w *Waiter) maxInterval() time.Duration {
+	if w.MaxInterval > 0 {
+		return w.MaxInterval
+	}
+	return defaultMaxInterval
+}
+
+func (w *Waiter) nextInterval(interval time.Duration) time.Duration {
+	multiplier, maxInterval := w.Multiplier, w.maxInterval()
+	if multiplier < 1 {
+		multiplier = defaultMultiplier
+	}
+
+	if next := time.Duration(float64(interval) * multiplier); next < maxInterval {
+		return next
+	}
+	return maxInterval
+}
+
+func (w *Waiter) jitter(interval time.Duration) time.Duration {
+	jitter := w.Jitter
+	if jitter <= 0 {
+		jitter = defaultJitter
+	}
+
+	// #nosec G404 jitter doesn't need a secure source of randomness
+	factor := 1 + jitter*(2*rand.Float64()-1)
+	return time.Duration(float64(interval) * factor)
+}
@@ -0,0 +1,178 @@
+package waiter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// sequence returns a RefreshFunc returning the given states one by one, and
+// then repeating the last one.
+func sequence(states ...string) RefreshFunc {
+	var i int
+	return func(context.Context) (string, bool, error) {
+		state := states[i]
+		if i < len(states)-1 {
+			i++
+		}
+		return state, false, nil
+	}
+}
+
+func fastWaiter(refresh RefreshFunc) *Waiter {
+	return &Waiter{
+		Name:        "test",
+		Refresh:     refresh,
+		MinInterval: time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+	}
+}
+
+func TestWaitAccepted(t *testing.T) {
+	w := fastWaiter(sequence("QUEUED", "RUNNING", "ACTIVE"))
+	w.Accepted = []string{"ACTIVE"}
+	w.Terminal = []string{"FAILED"}
+
+	state, err := w.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state != "ACTIVE" {
+		t.Fatalf("expected state ACTIVE, got %s", state)
+	}
+}
+
+func TestWaitUnexpectedTerminalState(t *testing.T) {
+	w := fastWaiter(sequence("QUEUED", "FAILED"))
+	w.Accepted = []string{"ACTIVE"}
+	w.Terminal = []string{"FAILED"}
+
+	state, err := w.Wait(context.Background())
+
+	var unexpected *UnexpectedStateError
+	if !errors.As(err, &unexpected) {
+		t.Fatalf("expected UnexpectedStateError, got %v", err)
+	}
+	if state != "FAILED" || unexpected.State != "FAILED" {
+		t.Fatalf("expected state FAILED, got %s", state)
+	}
+}
+
+func TestWaitTerminalFromRefresh(t *testing.T) {
+	var calls int
+	w := fastWaiter(func(context.Context) (string, bool, error) {
+		calls++
+		return "finished", calls == 3, nil
+	})
+
+	state, err := w.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state != "finished" || calls != 3 {
+		t.Fatalf("expected to finish after 3 calls, got state %s after %d calls", state, calls)
+	}
+}
+
+func TestWaitTimeout(t *testing.T) {
+	w := fastWaiter(sequence("RUNNING"))
+	w.Timeout = 20 * time.Millisecond
+
+	state, err := w.Wait(context.Background())
+
+	var timeout *TimeoutError
+	if !errors.As(err, &timeout) {
+		t.Fatalf("expected TimeoutError, got %v", err)
+	}
+	if state != "RUNNING" || timeout.LastState != "RUNNING" {
+		t.Fatalf("expected last state RUNNING, got %s", state)
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := fastWaiter(sequence("RUNNING")).Wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWaitRefreshError(t *testing.T) {
+	refreshErr := errors.New("boom")
+	w := fastWaiter(func(context.Context) (string, bool, error) {
+		return "", false, refreshErr
+	})
+
+	if _, err := w.Wait(context.Background()); !errors.Is(err, refreshErr) {
+		t.Fatalf("expected refresh error, got %v", err)
+	}
+}
+
+func TestWaitWatch(t *testing.T) {
+	var refreshes int
+	w := fastWaiter(func(context.Context) (string, bool, error) {
+		refreshes++
+		return "RUNNING", false, nil
+	})
+	w.MaxInterval = time.Hour
+	w.Watch = func(ctx context.Context, updates chan<- Update) error {
+		for _, update := range []Update{{State: "PLANNING"}, {State: "FINISHED", Terminal: true}} {
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	state, err := w.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state != "FINISHED" {
+		t.Fatalf("expected state FINISHED, got %s", state)
+	}
+	if refreshes > 1 {
+		t.Fatalf("expected at most 1 refresh while watching, got %d", refreshes)
+	}
+}
+
+func TestWaitWatchFallback(t *testing.T) {
+	w := fastWaiter(sequence("RUNNING", "RUNNING", "FINISHED"))
+	w.Accepted = []string{"FINISHED"}
+	w.Delay = time.Hour
+	w.Watch = func(context.Context, chan<- Update) error {
+		return errors.New("subscriptions unavailable")
+	}
+
+	state, err := w.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if state != "FINISHED" {
+		t.Fatalf("expected state FINISHED, got %s", state)
+	}
+}
+
+func TestIntervals(t *testing.T) {
+	w := &Waiter{MinInterval: time.Second, MaxInterval: 3 * time.Second, Multiplier: 2, Jitter: 0.1}
+
+	interval := w.minInterval()
+	for _, expected := range []time.Duration{2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		interval = w.nextInterval(interval)
+		if interval != expected {
+			t.Fatalf("expected interval %s, got %s", expected, interval)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		if jittered := w.jitter(time.Second); jittered < 900*time.Millisecond || jittered > 1100*time.Millisecond {
+			t.Fatalf("jittered interval %s out of bounds", jittered)
+		}
+	}
+}
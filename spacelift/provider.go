@@ -78,8 +78,11 @@ func Provider(commit, version string) plugin.ProviderFunc {
 				"spacelift_github_enterprise_integration":          dataGithubEnterpriseIntegration(),
 				"spacelift_gitlab_integration":                     dataGitlabIntegration(),
 				"spacelift_gitlab_webhook_endpoint":                dataGitlabWebhookEndpoint(),
+				"spacelift_gpg_keys":                               dataGPGKeys(),
 				"spacelift_ips":                                    dataIPs(),
 				"spacelift_module":                                 dataModule(),
+				"spacelift_modules":                                dataModules(),
+				"spacelift_module_versions":                        dataModuleVersions(),
 				"spacelift_mounted_file":                           dataMountedFile(),
 				"spacelift_policies":                               dataPolicies(),
 				"spacelift_policy":                                 dataPolicy(),
@@ -89,7 +92,12 @@ func Provider(commit, version string) plugin.ProviderFunc {
 				"spacelift_scheduled_task":                         dataScheduledTask(),
 				"spacelift_scheduled_delete_stack":                 dataScheduledDeleteStack(),
 				"spacelift_stack":                                  dataStack(),
+				"spacelift_stack_dependency_graph":                 dataStackDependencyGraph(),
+				"spacelift_stack_effective_config":                 dataStackEffectiveConfig(),
+				"spacelift_stack_state":                            dataStackState(),
 				"spacelift_stacks":                                 dataStacks(),
+				"spacelift_terraform_provider":                     dataTerraformProvider(),
+				"spacelift_terraform_providers":                    dataTerraformProviders(),
 				"spacelift_webhook":                                dataWebhook(),
 				"spacelift_named_webhook":                          dataNamedWebhook(),
 				"spacelift_stack_aws_role":                         dataStackAWSRole(),           // deprecated
@@ -112,9 +120,12 @@ func Provider(commit, version string) plugin.ProviderFunc {
 				"spacelift_context":                          resourceContext(),
 				"spacelift_drift_detection":                  resourceDriftDetection(),
 				"spacelift_environment_variable":             resourceEnvironmentVariable(),
+				"spacelift_environment_variables":            resourceEnvironmentVariables(),
 				"spacelift_gcp_service_account":              resourceGCPServiceAccount(),
+				"spacelift_gpg_key":                          resourceGPGKey(),
 				"spacelift_idp_group_mapping":                resourceIdpGroupMapping(),
 				"spacelift_module":                           resourceModule(),
+				"spacelift_module_test_run":                  resourceModuleTestRun(),
 				"spacelift_mounted_file":                     resourceMountedFile(),
 				"spacelift_policy_attachment":                resourcePolicyAttachment(),
 				"spacelift_policy":                           resourcePolicy(),
@@ -124,6 +135,7 @@ func Provider(commit, version string) plugin.ProviderFunc {
 				"spacelift_scheduled_delete_stack":           resourceScheduledDeleteStack(),
 				"spacelift_security_email":                   resourceSecurityEmail(),
 				"spacelift_stack":                            resourceStack(),
+				"spacelift_stack_dependencies":               resourceStackDependencies(),
 				"spacelift_stack_dependency":                 resourceStackDependency(),
 				"spacelift_stack_dependency_reference":       resourceStackDependencyReference(),
 				"spacelift_stack_activator":                  resourceStackActivator(),
@@ -131,7 +143,9 @@ func Provider(commit, version string) plugin.ProviderFunc {
 				"spacelift_stack_aws_role":                   resourceStackAWSRole(),           // deprecated
 				"spacelift_stack_gcp_service_account":        resourceStackGCPServiceAccount(), // deprecated
 				"spacelift_saved_filter":                     resourceSavedFilter(),
+				"spacelift_task":                             resourceTask(),
 				"spacelift_terraform_provider":               resourceTerraformProvider(),
+				"spacelift_terraform_provider_version":       resourceTerraformProviderVersion(),
 				"spacelift_user":                             resourceUser(),
 				"spacelift_vcs_agent_pool":                   resourceVCSAgentPool(),
 				"spacelift_webhook":                          resourceWebhook(),
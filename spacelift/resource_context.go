@@ -31,6 +31,7 @@ func resourceContext() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"adopt_existing": adoptExistingSchema("context"),
 			"after_apply": {
 				Type:        schema.TypeList,
 				Description: "List of after-apply scripts",
@@ -191,7 +192,14 @@ func resourceContextCreate(ctx context.Context, d *schema.ResourceData, meta int
 	variables := map[string]interface{}{"input": input}
 
 	if err := meta.(*internal.Client).Mutate(ctx, "ContextCreate", &mutation, variables); err != nil {
-		return diag.Errorf("could not create context: %v", internal.FromSpaceliftError(err))
+		diags := diag.Errorf("could not create context: %v", internal.FromSpaceliftError(err))
+
+		if d.Get("adopt_existing").(bool) {
+			slug, _ := computeSlug(d.Get("name").(string))
+			return adoptExisting(ctx, d, meta, "context", slug, err, diags, lookupExistingContext, resourceContextUpdate)
+		}
+
+		return diags
 	}
 
 	d.SetId(mutation.CreateContext.ID)
@@ -199,6 +207,24 @@ func resourceContextCreate(ctx context.Context, d *schema.ResourceData, meta int
 	return resourceContextRead(ctx, d, meta)
 }
 
+func lookupExistingContext(ctx context.Context, client *internal.Client, id string) (string, bool, error) {
+	var query struct {
+		Context *structs.Context `graphql:"context(id: $id)"`
+	}
+
+	variables := map[string]interface{}{"id": graphql.ID(id)}
+
+	if err := client.Query(ctx, "ContextRead", &query, variables); err != nil {
+		return "", false, err
+	}
+
+	if query.Context == nil {
+		return "", false, nil
+	}
+
+	return query.Context.Space, true, nil
+}
+
 func resourceContextRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var query struct {
 		Context *structs.Context `graphql:"context(id: $id)"`
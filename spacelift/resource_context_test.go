@@ -111,6 +111,41 @@ func TestContextResource(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("adopts an existing context", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		existing := fmt.Sprintf(`
+			resource "spacelift_context" "existing" {
+				name        = "Provider test context %s"
+				description = "adopted"
+				space_id    = "root"
+			}
+		`, randomID)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: existing,
+			},
+			{
+				Config: existing + fmt.Sprintf(`
+					resource "spacelift_context" "test" {
+						name           = "Provider test context %s"
+						description    = "adopted"
+						space_id       = "root"
+						adopt_existing = true
+						depends_on     = [spacelift_context.existing]
+					}
+				`, randomID),
+				Check: Resource(
+					resourceName,
+					Attribute("id", Equals(fmt.Sprintf("provider-test-context-%s", randomID))),
+					Attribute("description", Equals("adopted")),
+					Attribute("adopt_existing", Equals("true")),
+				),
+			},
+		})
+	})
 }
 
 func TestContextResourceSpace(t *testing.T) {
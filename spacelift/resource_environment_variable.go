@@ -28,8 +28,11 @@ func resourceEnvironmentVariable() *schema.Resource {
 
 		CreateContext: resourceEnvironmentVariableCreate,
 		ReadContext:   resourceEnvironmentVariableRead,
+		UpdateContext: resourceEnvironmentVariableUpdate,
 		DeleteContext: resourceEnvironmentVariableDelete,
 
+		CustomizeDiff: customizeDiffConfigChecksum("value"),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -73,14 +76,12 @@ func resourceEnvironmentVariable() *schema.Resource {
 				Sensitive:        true,
 				Optional:         true,
 				Default:          "",
-				ForceNew:         true,
 			},
 			"write_only": {
 				Type:        schema.TypeBool,
 				Description: "Indicates whether the value is secret or not. Defaults to `true`.",
 				Optional:    true,
 				Default:     true,
-				ForceNew:    true,
 			},
 		},
 	}
@@ -267,6 +268,28 @@ func resourceEnvironmentVariableReadStack(ctx context.Context, d *schema.Resourc
 	return query.Stack.ConfigElement, nil
 }
 
+func resourceEnvironmentVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	idParts := strings.SplitN(d.Id(), "/", 3)
+	if len(idParts) != 3 {
+		return diag.Errorf("unexpected resource ID: %s", d.Id())
+	}
+
+	config := structs.ConfigInput{
+		ID:        toID(idParts[2]),
+		Type:      structs.ConfigType("ENVIRONMENT_VARIABLE"),
+		Value:     graphql.String(configuredValue(d, "value")),
+		WriteOnly: graphql.Boolean(d.Get("write_only").(bool)),
+	}
+
+	// Adding a config element with an existing ID overwrites it, so there is
+	// no window in which the variable is missing.
+	if err := addConfigElement(ctx, meta.(*internal.Client), idParts[0], idParts[1], config); err != nil {
+		return diag.Errorf("could not update environment variable: %v", internal.FromSpaceliftError(err))
+	}
+
+	return resourceEnvironmentVariableRead(ctx, d, meta)
+}
+
 func resourceEnvironmentVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	idParts := strings.SplitN(d.Id(), "/", 3)
 	if len(idParts) != 3 {
@@ -320,3 +343,50 @@ func suppressValueChange(_, old, new string, d *schema.ResourceData) bool {
 
 	return subtle.ConstantTimeCompare(newValueChecksum[:], oldValueChecksum) == 1
 }
+
+// configuredValue returns the value of a config element as configured. For a
+// write-only element the state holds the checksum instead, and that is what
+// d.Get returns if only the secrecy changes, since the value diff is then
+// suppressed.
+func configuredValue(d *schema.ResourceData, valueKey string) string {
+	value := d.GetRawConfig().GetAttr(valueKey)
+	if value.IsNull() || !value.IsKnown() {
+		return ""
+	}
+
+	return value.AsString()
+}
+
+// addConfigElement adds a config element to a context, stack or module, or
+// overwrites the one with the same ID.
+func addConfigElement(ctx context.Context, client *internal.Client, resourceType, resourceID string, config structs.ConfigInput) error {
+	if resourceType == "context" {
+		var mutation struct {
+			AddContextConfig structs.ConfigElement `graphql:"contextConfigAdd(context: $context, config: $config)"`
+		}
+
+		return client.Mutate(ctx, "ConfigElementAddContext", &mutation, map[string]interface{}{"context": toID(resourceID), "config": config})
+	}
+
+	var mutation struct {
+		AddStackConfig structs.ConfigElement `graphql:"stackConfigAdd(stack: $stack, config: $config)"`
+	}
+
+	return client.Mutate(ctx, "ConfigElementAddStack", &mutation, map[string]interface{}{"stack": toID(resourceID), "config": config})
+}
+
+// customizeDiffConfigChecksum marks the checksum of a config element as
+// unknown whenever its value or secrecy is about to change in place.
+func customizeDiffConfigChecksum(valueKey string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		if d.HasChange(valueKey) || d.HasChange("write_only") {
+			return d.SetNewComputed("checksum")
+		}
+
+		return nil
+	}
+}
@@ -14,7 +14,7 @@ func TestEnvironmentVariableResource(t *testing.T) {
 	t.Run("with a context", func(t *testing.T) {
 		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
 
-		config := func(writeOnly bool) string {
+		config := func(value string, writeOnly bool) string {
 			return fmt.Sprintf(`
 				resource "spacelift_context" "test" {
 					name = "My first context %s"
@@ -23,17 +23,17 @@ func TestEnvironmentVariableResource(t *testing.T) {
 				resource "spacelift_environment_variable" "test" {
 					context_id = spacelift_context.test.id
 					name       = "BACON"
-					value      = "is tasty"
+					value      = "%s"
 					write_only = %t
 				}
-			`, randomID, writeOnly)
+			`, randomID, value, writeOnly)
 		}
 
 		const resourceName = "spacelift_environment_variable.test"
 
 		testSteps(t, []resource.TestStep{
 			{
-				Config: config(true),
+				Config: config("is tasty", true),
 				Check: Resource(
 					"spacelift_environment_variable.test",
 					Attribute("id", IsNotEmpty()),
@@ -52,13 +52,23 @@ func TestEnvironmentVariableResource(t *testing.T) {
 				ImportStateVerify: true,
 			},
 			{
-				Config: config(false),
+				Config: config("is tasty", false),
 				Check: Resource(
 					"spacelift_environment_variable.test",
+					Attribute("checksum", Equals("4d5d01ea427b10dd483e8fce5b5149fb5a9814e9ee614176b756ca4a65c8f154")),
 					Attribute("value", Equals("is tasty")),
 					Attribute("write_only", Equals("false")),
 				),
 			},
+			{
+				Config: config("is crispy", true),
+				Check: Resource(
+					"spacelift_environment_variable.test",
+					Attribute("checksum", Equals("c14854e389a45f71b3640b56b693603ca9d8a665c1d08a450939ff17296220fb")),
+					Attribute("value", Equals("c14854e389a45f71b3640b56b693603ca9d8a665c1d08a450939ff17296220fb")),
+					Attribute("write_only", Equals("true")),
+				),
+			},
 		})
 	})
 
@@ -0,0 +1,426 @@
+package spacelift
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/graphql"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+func resourceEnvironmentVariables() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_environment_variables` manages a set of environment " +
+			"variables on a single context (`spacelift_context`), stack " +
+			"(`spacelift_stack`) or module (`spacelift_module`). Only the " +
+			"variables whose value or secrecy changed are written on update. " +
+			"In `exclusive` mode, variables which are not declared in the " +
+			"resource are deleted.",
+
+		CreateContext: resourceEnvironmentVariablesCreate,
+		ReadContext:   resourceEnvironmentVariablesRead,
+		UpdateContext: resourceEnvironmentVariablesUpdate,
+		DeleteContext: resourceEnvironmentVariablesDelete,
+
+		CustomizeDiff: resourceEnvironmentVariablesCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEnvironmentVariablesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"checksums": {
+				Type:        schema.TypeMap,
+				Description: "SHA-256 checksums of the values, keyed by variable name",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+			"context_id": {
+				Type:         schema.TypeString,
+				Description:  "ID of the context on which the environment variables are defined",
+				Optional:     true,
+				ExactlyOneOf: []string{"context_id", "stack_id", "module_id"},
+				ForceNew:     true,
+			},
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Description: "Delete environment variables which are not declared in this resource. Defaults to `false`.",
+				Optional:    true,
+			},
+			"module_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the module on which the environment variables are defined",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"stack_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the stack on which the environment variables are defined",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"variable": {
+				Type:        schema.TypeSet,
+				Description: "Environment variable to define",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Description:      "Name of the environment variable",
+							Required:         true,
+							ValidateDiagFunc: validations.DisallowEmptyString,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "Value of the environment variable. Defaults to an empty string.",
+							Sensitive:   true,
+							Optional:    true,
+							Default:     "",
+						},
+						"write_only": {
+							Type:        schema.TypeBool,
+							Description: "Indicates whether the value is secret or not. Defaults to `true`.",
+							Optional:    true,
+							Default:     true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceEnvironmentVariablesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	resourceType, resourceID, err := configTarget(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The ID is set first so that the variables written before any failure are
+	// still tracked, and the state is refreshed to contain only those.
+	d.SetId(fmt.Sprintf("%s/%s", resourceType, resourceID))
+
+	if diags := resourceEnvironmentVariablesApply(ctx, d, meta.(*internal.Client), resourceType, resourceID); diags.HasError() {
+		return append(diags, resourceEnvironmentVariablesRead(ctx, d, meta)...)
+	}
+
+	return resourceEnvironmentVariablesRead(ctx, d, meta)
+}
+
+func resourceEnvironmentVariablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceEnvironmentVariablesReadAll(ctx, d, meta.(*internal.Client), d.Get("exclusive").(bool))
+}
+
+func resourceEnvironmentVariablesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// There is nothing in the state yet to tell us which variables are
+	// managed, so let's take all of them.
+	if diags := resourceEnvironmentVariablesReadAll(ctx, d, meta.(*internal.Client), true); diags.HasError() {
+		return nil, errors.New(diags[0].Summary)
+	}
+
+	if d.Id() == "" {
+		return nil, errors.New("environment variables target not found")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceEnvironmentVariablesReadAll refreshes the variables tracked in the
+// state. If all is set, every variable defined on the target is read instead.
+func resourceEnvironmentVariablesReadAll(ctx context.Context, d *schema.ResourceData, client *internal.Client, all bool) diag.Diagnostics {
+	resourceType, resourceID, err := environmentVariablesTarget(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	elements, err := listConfigElements(ctx, client, resourceType, resourceID, structs.ConfigType("ENVIRONMENT_VARIABLE"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if elements == nil {
+		d.SetId("")
+		return nil
+	}
+
+	known := make(map[string]string)
+	for _, item := range d.Get("variable").(*schema.Set).List() {
+		variable := item.(map[string]interface{})
+		known[variable["name"].(string)] = variable["value"].(string)
+	}
+
+	checksums := make(map[string]interface{})
+	var variables []interface{}
+
+	for name, element := range elements {
+		knownValue, tracked := known[name]
+		if !tracked && !all {
+			continue
+		}
+
+		// The value of a write-only variable is never returned, so we keep the
+		// one from the state as long as it still matches the checksum.
+		value := element.Checksum
+		if element.Value != nil {
+			value = *element.Value
+		} else if tracked && configChecksum(knownValue) == element.Checksum {
+			value = knownValue
+		}
+
+		checksums[name] = element.Checksum
+		variables = append(variables, map[string]interface{}{
+			"name":       name,
+			"value":      value,
+			"write_only": element.WriteOnly,
+		})
+	}
+
+	d.Set(resourceType+"_id", resourceID)
+	d.Set("checksums", checksums)
+
+	if err := d.Set("variable", variables); err != nil {
+		return diag.Errorf("could not set environment variables: %v", err)
+	}
+
+	return nil
+}
+
+// resourceEnvironmentVariablesCustomizeDiff rejects variables declared more
+// than once, which would otherwise overwrite each other on every apply.
+func resourceEnvironmentVariablesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("variable") {
+		return nil
+	}
+
+	declared := make(map[string]bool)
+
+	for _, item := range d.Get("variable").(*schema.Set).List() {
+		name := item.(map[string]interface{})["name"].(string)
+
+		if declared[name] {
+			return errors.Errorf("environment variable %s is declared more than once", name)
+		}
+		declared[name] = true
+	}
+
+	return nil
+}
+
+func resourceEnvironmentVariablesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	resourceType, resourceID, err := environmentVariablesTarget(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := resourceEnvironmentVariablesApply(ctx, d, meta.(*internal.Client), resourceType, resourceID); diags.HasError() {
+		return diags
+	}
+
+	return resourceEnvironmentVariablesRead(ctx, d, meta)
+}
+
+// resourceEnvironmentVariablesApply writes the variables whose checksum or
+// secrecy changed and deletes the ones no longer declared.
+func resourceEnvironmentVariablesApply(ctx context.Context, d *schema.ResourceData, client *internal.Client, resourceType, resourceID string) diag.Diagnostics {
+	oldRaw, newRaw := d.GetChange("variable")
+
+	previous := make(map[string]map[string]interface{})
+	for _, item := range oldRaw.(*schema.Set).List() {
+		variable := item.(map[string]interface{})
+		previous[variable["name"].(string)] = variable
+	}
+
+	declared := make(map[string]bool)
+
+	for _, item := range newRaw.(*schema.Set).List() {
+		variable := item.(map[string]interface{})
+		name, value, writeOnly := variable["name"].(string), variable["value"].(string), variable["write_only"].(bool)
+
+		declared[name] = true
+
+		if old, ok := previous[name]; ok && old["write_only"].(bool) == writeOnly && configChecksum(old["value"].(string)) == configChecksum(value) {
+			continue
+		}
+
+		config := structs.ConfigInput{
+			ID:        toID(name),
+			Type:      structs.ConfigType("ENVIRONMENT_VARIABLE"),
+			Value:     graphql.String(value),
+			WriteOnly: graphql.Boolean(writeOnly),
+		}
+
+		if err := addConfigElement(ctx, client, resourceType, resourceID, config); err != nil {
+			return diag.Errorf("could not set environment variable %s: %v", name, internal.FromSpaceliftError(err))
+		}
+	}
+
+	var undeclared []string
+
+	for name := range previous {
+		if !declared[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+
+	if d.Get("exclusive").(bool) {
+		elements, err := listConfigElements(ctx, client, resourceType, resourceID, structs.ConfigType("ENVIRONMENT_VARIABLE"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for name := range elements {
+			if _, ok := previous[name]; !ok && !declared[name] {
+				undeclared = append(undeclared, name)
+			}
+		}
+	}
+
+	for _, name := range undeclared {
+		if err := deleteEnvironmentVariable(ctx, d, client, resourceType, resourceID, name); err != nil {
+			return diag.Errorf("could not delete environment variable %s: %v", name, internal.FromSpaceliftError(err))
+		}
+	}
+
+	return nil
+}
+
+func resourceEnvironmentVariablesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	resourceType, resourceID, err := environmentVariablesTarget(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*internal.Client)
+
+	for _, item := range d.Get("variable").(*schema.Set).List() {
+		name := item.(map[string]interface{})["name"].(string)
+
+		if err := deleteEnvironmentVariable(ctx, d, client, resourceType, resourceID, name); err != nil {
+			return diag.Errorf("could not delete environment variable %s: %v", name, internal.FromSpaceliftError(err))
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// configTarget returns the type and ID of the context, stack or module the
+// config is defined on, verifying that the stack or module exists.
+func configTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) (resourceType, resourceID string, err error) {
+	if contextID, ok := d.GetOk("context_id"); ok {
+		return "context", contextID.(string), nil
+	}
+
+	if stackID, ok := d.GetOk("stack_id"); ok {
+		return "stack", stackID.(string), verifyStack(ctx, stackID.(string), meta)
+	}
+
+	moduleID := d.Get("module_id").(string)
+	return "module", moduleID, verifyModule(ctx, moduleID, meta)
+}
+
+func environmentVariablesTarget(id string) (resourceType, resourceID string, err error) {
+	idParts := strings.SplitN(id, "/", 2)
+	if len(idParts) != 2 {
+		return "", "", errors.Errorf("unexpected resource ID: %s", id)
+	}
+
+	switch idParts[0] {
+	case "context", "module", "stack":
+		return idParts[0], idParts[1], nil
+	default:
+		return "", "", errors.Errorf("unexpected resource type: %s", idParts[0])
+	}
+}
+
+// listConfigElements returns the config elements of the given type defined on
+// the target, keyed by ID, or nil if the target does not exist.
+func listConfigElements(ctx context.Context, client *internal.Client, resourceType, resourceID string, configType structs.ConfigType) (map[string]structs.ConfigElement, error) {
+	var config []structs.ConfigElement
+	variables := map[string]interface{}{"id": toID(resourceID)}
+
+	switch resourceType {
+	case "context":
+		var query struct {
+			Context *struct {
+				Config []structs.ConfigElement `graphql:"config"`
+			} `graphql:"context(id: $id)"`
+		}
+
+		if err := client.Query(ctx, "ConfigElementsReadContext", &query, variables); err != nil {
+			return nil, errors.Wrap(err, "could not query for context config")
+		}
+
+		if query.Context == nil {
+			return nil, nil
+		}
+
+		config = query.Context.Config
+	case "module":
+		var query struct {
+			Module *struct {
+				Config []structs.ConfigElement `graphql:"config"`
+			} `graphql:"module(id: $id)"`
+		}
+
+		if err := client.Query(ctx, "ConfigElementsReadModule", &query, variables); err != nil {
+			return nil, errors.Wrap(err, "could not query for module config")
+		}
+
+		if query.Module == nil {
+			return nil, nil
+		}
+
+		config = query.Module.Config
+	default:
+		var query struct {
+			Stack *struct {
+				Config []structs.ConfigElement `graphql:"config"`
+			} `graphql:"stack(id: $id)"`
+		}
+
+		if err := client.Query(ctx, "ConfigElementsReadStack", &query, variables); err != nil {
+			return nil, errors.Wrap(err, "could not query for stack config")
+		}
+
+		if query.Stack == nil {
+			return nil, nil
+		}
+
+		config = query.Stack.Config
+	}
+
+	elements := make(map[string]structs.ConfigElement)
+	for _, element := range config {
+		if element.Type == configType {
+			elements[element.ID] = element
+		}
+	}
+
+	return elements, nil
+}
+
+func deleteEnvironmentVariable(ctx context.Context, d *schema.ResourceData, client *internal.Client, resourceType, resourceID, name string) error {
+	if resourceType == "context" {
+		return resourceEnvironmentVariableDeleteContext(ctx, d, client, resourceID, name)
+	}
+
+	return resourceEnvironmentVariableDeleteStack(ctx, d, client, resourceID, name)
+}
+
+// configChecksum returns the checksum Spacelift reports for a config value.
+func configChecksum(value string) string {
+	checksum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(checksum[:])
+}
@@ -0,0 +1,139 @@
+package spacelift
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestEnvironmentVariablesResource(t *testing.T) {
+	t.Run("with a context", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		config := func(value string, writeOnly bool) string {
+			return fmt.Sprintf(`
+				resource "spacelift_context" "test" {
+					name = "My first context %s"
+				}
+
+				resource "spacelift_environment_variables" "test" {
+					context_id = spacelift_context.test.id
+					exclusive  = true
+
+					variable {
+						name  = "BACON"
+						value = "is tasty"
+					}
+
+					variable {
+						name       = "CABBAGE"
+						value      = "%s"
+						write_only = %t
+					}
+				}
+			`, randomID, value, writeOnly)
+		}
+
+		const resourceName = "spacelift_environment_variables.test"
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: config("is healthy", true),
+				Check: Resource(
+					resourceName,
+					Attribute("id", StartsWith("context/")),
+					Attribute("context_id", Contains(randomID)),
+					Attribute("exclusive", Equals("true")),
+					Attribute("variable.#", Equals("2")),
+					Attribute("checksums.BACON", Equals("4d5d01ea427b10dd483e8fce5b5149fb5a9814e9ee614176b756ca4a65c8f154")),
+					Attribute("checksums.CABBAGE", IsNotEmpty()),
+					AttributeNotPresent("module_id"),
+					AttributeNotPresent("stack_id"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"exclusive"},
+			},
+			{
+				Config: config("is tasty", false),
+				Check: Resource(
+					resourceName,
+					Attribute("variable.#", Equals("2")),
+					Attribute("checksums.CABBAGE", Equals("4d5d01ea427b10dd483e8fce5b5149fb5a9814e9ee614176b756ca4a65c8f154")),
+				),
+			},
+		})
+	})
+
+	t.Run("with a stack", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_stack" "test" {
+						branch     = "master"
+						repository = "demo"
+						name       = "Test stack %s"
+					}
+
+					resource "spacelift_environment_variables" "test" {
+						stack_id = spacelift_stack.test.id
+
+						variable {
+							name       = "BACON"
+							value      = "is tasty"
+							write_only = false
+						}
+					}
+				`, randomID),
+				Check: Resource(
+					"spacelift_environment_variables.test",
+					Attribute("id", StartsWith("stack/")),
+					Attribute("stack_id", Contains(randomID)),
+					Attribute("variable.#", Equals("1")),
+					Attribute("checksums.BACON", Equals("4d5d01ea427b10dd483e8fce5b5149fb5a9814e9ee614176b756ca4a65c8f154")),
+					AttributeNotPresent("context_id"),
+					AttributeNotPresent("module_id"),
+				),
+			},
+		})
+	})
+
+	t.Run("rejects duplicate names", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_context" "test" {
+						name = "My first context %s"
+					}
+
+					resource "spacelift_environment_variables" "test" {
+						context_id = spacelift_context.test.id
+
+						variable {
+							name  = "BACON"
+							value = "is tasty"
+						}
+
+						variable {
+							name  = "BACON"
+							value = "is crispy"
+						}
+					}
+				`, randomID),
+				ExpectError: regexp.MustCompile(`environment variable BACON is declared more than once`),
+			},
+		})
+	})
+}
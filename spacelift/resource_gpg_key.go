@@ -0,0 +1,205 @@
+package spacelift
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/graphql"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+func resourceGPGKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_gpg_key` represents a GPG public key registered for " +
+			"verifying the signatures of Terraform provider versions published " +
+			"to Spacelift's own provider registry. Keys can't be deleted, so " +
+			"destroying the resource revokes the key.",
+
+		CreateContext: resourceGPGKeyCreate,
+		ReadContext:   resourceGPGKeyRead,
+		DeleteContext: resourceGPGKeyDelete,
+
+		CustomizeDiff: resourceGPGKeyCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Description:      "Name of the key",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Description: "Free-form description of the key",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"ascii_armor": {
+				Type:             schema.TypeString,
+				Description:      "ASCII-armored public key",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateGPGKey,
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Description: "Long ID of the key, e.g. `3AA5C34371567BD2`",
+				Computed:    true,
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Description: "Fingerprint of the key",
+				Computed:    true,
+			},
+			"created_at": {
+				Type:        schema.TypeInt,
+				Description: "Unix timestamp at which the key was registered",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// inspectGPGKey returns the long ID and the fingerprint of an ASCII-armored
+// public key.
+func inspectGPGKey(asciiArmor string) (keyID, fingerprint string, err error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(asciiArmor))
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not read GPG key")
+	}
+
+	if len(entities) != 1 {
+		return "", "", errors.Errorf("expected a single GPG key, found %d", len(entities))
+	}
+
+	key := entities[0].PrimaryKey
+
+	return key.KeyIdString(), strings.ToUpper(hex.EncodeToString(key.Fingerprint)), nil
+}
+
+func validateGPGKey(value interface{}, path cty.Path) diag.Diagnostics {
+	if _, _, err := inspectGPGKey(value.(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceGPGKeyCustomizeDiff computes the ID and fingerprint of a new key, so
+// that they're known at plan time.
+func resourceGPGKeyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || !d.NewValueKnown("ascii_armor") {
+		return nil
+	}
+
+	keyID, fingerprint, err := inspectGPGKey(d.Get("ascii_armor").(string))
+	if err != nil {
+		return err
+	}
+
+	if err := d.SetNew("key_id", keyID); err != nil {
+		return err
+	}
+
+	return d.SetNew("fingerprint", fingerprint)
+}
+
+func resourceGPGKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	asciiArmor := d.Get("ascii_armor").(string)
+
+	keyID, fingerprint, err := inspectGPGKey(asciiArmor)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var mutation struct {
+		CreateGPGKey structs.GPGKey `graphql:"gpgKeyCreate(name: $name, description: $description, asciiArmor: $asciiArmor)"`
+	}
+
+	variables := map[string]interface{}{
+		"name":        toString(d.Get("name")),
+		"description": (*graphql.String)(nil),
+		"asciiArmor":  toString(asciiArmor),
+	}
+
+	if description, ok := d.GetOk("description"); ok {
+		variables["description"] = toOptionalString(description)
+	}
+
+	if err := meta.(*internal.Client).Mutate(ctx, "GPGKeyCreate", &mutation, variables); err != nil {
+		return diag.Errorf("could not create GPG key: %v", internal.FromSpaceliftError(err))
+	}
+
+	d.SetId(mutation.CreateGPGKey.ID)
+	d.Set("key_id", keyID)
+	d.Set("fingerprint", fingerprint)
+
+	return resourceGPGKeyRead(ctx, d, meta)
+}
+
+func resourceGPGKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keys, err := getGPGKeys(ctx, meta.(*internal.Client))
+	if err != nil {
+		return diag.Errorf("could not query for GPG keys: %v", internal.FromSpaceliftError(err))
+	}
+
+	for _, key := range keys {
+		if key.ID != d.Id() {
+			continue
+		}
+
+		// Revoked keys can't be used anymore, so they're as good as gone.
+		if key.RevokedAt != nil {
+			break
+		}
+
+		d.Set("name", key.Name)
+		d.Set("description", key.Description)
+		d.Set("created_at", key.CreatedAt)
+
+		return nil
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceGPGKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var mutation struct {
+		RevokeGPGKey *structs.GPGKey `graphql:"gpgKeyRevoke(id: $id)"`
+	}
+
+	variables := map[string]interface{}{"id": toID(d.Id())}
+
+	if err := meta.(*internal.Client).Mutate(ctx, "GPGKeyRevoke", &mutation, variables); err != nil {
+		return diag.Errorf("could not revoke GPG key: %v", internal.FromSpaceliftError(err))
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func getGPGKeys(ctx context.Context, client *internal.Client) ([]structs.GPGKey, error) {
+	var query struct {
+		GPGKeys []structs.GPGKey `graphql:"gpgKeys"`
+	}
+
+	if err := client.Query(ctx, "GPGKeysRead", &query, map[string]interface{}{}); err != nil {
+		return nil, err
+	}
+
+	return query.GPGKeys, nil
+}
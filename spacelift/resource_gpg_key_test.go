@@ -0,0 +1,83 @@
+package spacelift
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestGPGKeyResource(t *testing.T) {
+	const resourceName = "spacelift_gpg_key.test"
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	asciiArmor, keyID := testGPGKey(t)
+
+	testSteps(t, []resource.TestStep{
+		{
+			Config: fmt.Sprintf(`
+				resource "spacelift_gpg_key" "test" {
+					name        = "provider-test-key-%s"
+					description = "test key"
+					ascii_armor = <<EOF
+%sEOF
+				}
+			`, randomID, asciiArmor),
+			Check: Resource(
+				resourceName,
+				Attribute("id", IsNotEmpty()),
+				Attribute("name", Equals(fmt.Sprintf("provider-test-key-%s", randomID))),
+				Attribute("description", Equals("test key")),
+				Attribute("key_id", Equals(keyID)),
+				Attribute("fingerprint", Contains(keyID)),
+				Attribute("created_at", IsNotEmpty()),
+			),
+		},
+	})
+}
+
+func testGPGKey(t *testing.T) (asciiArmor, keyID string) {
+	t.Helper()
+
+	entity := testGPGEntity(t)
+
+	return testGPGKeyArmor(t, entity), entity.PrimaryKey.KeyIdString()
+}
+
+// testGPGEntity generates a key pair which can be used for signing.
+func testGPGEntity(t *testing.T) *openpgp.Entity {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("Provider test", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return entity
+}
+
+// testGPGKeyArmor returns the ASCII-armored public key of the entity.
+func testGPGKeyArmor(t *testing.T, entity *openpgp.Entity) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	writer, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(writer); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.String() + "\n"
+}
@@ -18,13 +18,20 @@ func resourceModule() *schema.Resource {
 	return &schema.Resource{
 		Description: "" +
 			"`spacelift_module` is a special type of a stack used to test and " +
-			"version Terraform modules.",
+			"version Terraform modules. " +
+			"The `slug` of a new module is computed from its name at plan time, " +
+			"but its `id` stays unknown until it's created, so other resources " +
+			"should reference `slug` to be planned in the same run.",
 
 		CreateContext: resourceModuleCreate,
 		ReadContext:   resourceModuleRead,
 		UpdateContext: resourceModuleUpdate,
 		DeleteContext: resourceModuleDelete,
 
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return planSlug(d, "slug")
+		},
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -265,6 +272,11 @@ func resourceModule() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 			},
+			"slug": {
+				Type:        schema.TypeString,
+				Description: "ID (slug) of the module. If `name` is set, it's computed at plan time, so unlike `id` it can be referenced before the module is created. Resources depending on the module should reference `slug` rather than `id`, which is unknown until the module is created.",
+				Computed:    true,
+			},
 			"terraform_provider": {
 				Type:        schema.TypeString,
 				Description: "The module provider will by default be inferred from the repository name if it follows the terraform-provider-name naming convention. However, if the repository doesn't follow this convention, or you gave the module a custom name, you can provide the provider name here.",
@@ -300,9 +312,15 @@ func resourceModuleCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		return diag.Errorf("could not create module: %v", internal.FromSpaceliftError(err))
 	}
 
+	planned := d.Get("slug").(string)
+
 	d.SetId(mutation.CreateModule.ID)
 
-	return resourceModuleRead(ctx, d, meta)
+	if diags := resourceModuleRead(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return checkSlug("module", planned, mutation.CreateModule.ID)
 }
 
 func resourceModuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -326,6 +344,7 @@ func resourceModuleRead(ctx context.Context, d *schema.ResourceData, meta interf
 	d.Set("administrative", module.Administrative)
 	d.Set("branch", module.Branch)
 	d.Set("name", module.Name)
+	d.Set("slug", module.ID)
 	d.Set("enable_local_preview", module.LocalPreviewEnabled)
 	d.Set("protect_from_deletion", module.ProtectFromDeletion)
 	d.Set("repository", module.Repository)
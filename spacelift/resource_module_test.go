@@ -39,6 +39,7 @@ func TestModuleResource(t *testing.T) {
 				Check: Resource(
 					"spacelift_module.test",
 					Attribute("id", Equals(fmt.Sprintf("github-module-%s", randomID))),
+					Attribute("slug", Equals(fmt.Sprintf("github-module-%s", randomID))),
 					Attribute("administrative", Equals("true")),
 					Attribute("branch", Equals("master")),
 					Attribute("description", Equals("old description")),
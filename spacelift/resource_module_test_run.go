@@ -0,0 +1,296 @@
+package spacelift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/graphql"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+func resourceModuleTestRun() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_module_test_run` allows programmatically running the test " +
+			"cases of a module for a commit, without publishing a version, in " +
+			"response to arbitrary changes in the keepers section. Test case " +
+			"runs can't be confirmed or stopped, so `wait` doesn't support the " +
+			"`on_unconfirmed` and `cancel_on_*` options.",
+
+		CreateContext: resourceModuleTestRunCreate,
+		ReadContext:   resourceModuleTestRunRead,
+		Delete:        schema.RemoveFromState,
+		UpdateContext: schema.NoopContext,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"module_id": {
+				Type:             schema.TypeString,
+				Description:      "ID of the module whose test cases are to be run.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"commit_sha": {
+				Description: "The commit SHA for which to run the test cases. Defaults to the head of the tracked branch.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"keepers": {
+				Description: "" +
+					"Arbitrary map of values that, when changed, will trigger " +
+					"recreation of the resource.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"id": {
+				Description: "The ID of the proposed version the test cases are run for.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"passed": {
+				Description: "Whether all the test cases have finished successfully. False if the module has no test cases.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"test_cases": {
+				Description: "Runs of the test cases of the module.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the test case",
+							Computed:    true,
+						},
+						"run_id": {
+							Type:        schema.TypeString,
+							Description: "ID of the run of the test case",
+							Computed:    true,
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Description: "The last known state of the run, lowercased (e.g. `finished`, `failed`).",
+							Computed:    true,
+						},
+						"passed": {
+							Type:        schema.TypeBool,
+							Description: "Whether the run has finished successfully",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"wait": moduleTestRunWaitSchema(),
+		},
+	}
+}
+
+// moduleTestRunWaitSchema reuses the options of waiting for a run, except for
+// the ones confirming or stopping it, which test case runs don't support.
+func moduleTestRunWaitSchema() *schema.Schema {
+	wait := waitConfigurationSchema()
+
+	fields := wait.Elem.(*schema.Resource).Schema
+	for _, field := range []string{"on_unconfirmed", "cancel_on_interrupt", "cancel_on_timeout"} {
+		delete(fields, field)
+	}
+
+	return wait
+}
+
+func expandModuleTestRunWaitConfiguration(input []interface{}) *waitConfiguration {
+	if len(input) == 0 {
+		return nil
+	}
+
+	v := map[string]interface{}{
+		"cancel_on_interrupt": false,
+		"cancel_on_timeout":   false,
+	}
+	for key, value := range input[0].(map[string]interface{}) {
+		v[key] = value
+	}
+
+	return expandWaitConfiguration([]interface{}{v})
+}
+
+func resourceModuleTestRunCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var mutation struct {
+		Version struct {
+			ID   string `graphql:"id"`
+			Runs []struct {
+				ID    string `graphql:"id"`
+				Title string `graphql:"title"`
+			} `graphql:"runs"`
+		} `graphql:"versionPropose(module: $module, commitSha: $sha)"`
+	}
+
+	moduleID := d.Get("module_id").(string)
+
+	variables := map[string]interface{}{
+		"module": toID(moduleID),
+		"sha":    (*graphql.String)(nil),
+	}
+
+	if sha, ok := d.GetOk("commit_sha"); ok {
+		variables["sha"] = toOptionalString(sha)
+	}
+
+	client := meta.(*internal.Client)
+	if err := client.Mutate(ctx, "ModuleTestRunCreate", &mutation, variables); err != nil {
+		return diag.Errorf("could not trigger test cases for module %s: %v", moduleID, internal.FromSpaceliftError(err))
+	}
+
+	testCases := make([]interface{}, 0, len(mutation.Version.Runs))
+	for _, run := range mutation.Version.Runs {
+		testCases = append(testCases, map[string]interface{}{
+			"name":   run.Title,
+			"run_id": run.ID,
+		})
+	}
+
+	// Let's save the state before waiting, so that the proposed version is
+	// tracked even if waiting for it fails.
+	d.SetId(mutation.Version.ID)
+
+	if err := d.Set("test_cases", testCases); err != nil {
+		return diag.Errorf("could not set test cases: %v", err)
+	}
+
+	var diags diag.Diagnostics
+
+	if waitRaw, ok := d.GetOk("wait"); ok {
+		wait := expandModuleTestRunWaitConfiguration(waitRaw.([]interface{}))
+		deadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
+
+		// The test cases run in parallel, so let's report all the ones which
+		// didn't end as expected rather than just the first.
+		for _, run := range mutation.Version.Runs {
+			diags = append(diags, waitForModuleRun(ctx, wait, time.Until(deadline), client, moduleID, run.ID)...)
+		}
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceModuleTestRunRead(ctx, d, meta)...)
+}
+
+func resourceModuleTestRunRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*internal.Client)
+	moduleID := d.Get("module_id").(string)
+
+	known := d.Get("test_cases").([]interface{})
+
+	testCases := []interface{}{}
+	passed := len(known) > 0
+
+	for _, item := range known {
+		testCase := item.(map[string]interface{})
+		runID := testCase["run_id"].(string)
+
+		run, err := getModuleRun(ctx, client, moduleID, runID)
+		if err != nil {
+			return diag.Errorf("could not query for run %s of module %s: %v", runID, moduleID, internal.FromSpaceliftError(err))
+		}
+
+		if run == nil {
+			continue
+		}
+
+		state := strings.ToLower(run.State)
+		testCase["state"] = state
+		testCase["passed"] = state == "finished"
+		passed = passed && state == "finished"
+
+		d.Set("commit_sha", run.Commit.Hash)
+
+		testCases = append(testCases, testCase)
+	}
+
+	if len(known) > 0 && len(testCases) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("passed", passed)
+
+	if err := d.Set("test_cases", testCases); err != nil {
+		return diag.Errorf("could not set test cases: %v", err)
+	}
+
+	return nil
+}
+
+// getModuleRun returns the run with the given ID on the given module, or nil
+// if either of them does not exist.
+func getModuleRun(ctx context.Context, client *internal.Client, moduleID, runID string) (*structs.Run, error) {
+	var query struct {
+		Module *struct {
+			Run *structs.Run `graphql:"run(id: $runId)"`
+		} `graphql:"module(id: $moduleId)"`
+	}
+
+	variables := map[string]interface{}{
+		"moduleId": toID(moduleID),
+		"runId":    toID(runID),
+	}
+
+	if err := client.Query(ctx, "ModuleRunRead", &query, variables); err != nil {
+		return nil, err
+	}
+
+	if query.Module == nil {
+		return nil, nil
+	}
+
+	return query.Module.Run, nil
+}
+
+// waitForModuleRun waits for a test case run of a module. These runs never
+// need confirmation and can't be stopped, so on_unconfirmed and the cancel
+// options don't apply to them.
+func waitForModuleRun(ctx context.Context, wait *waitConfiguration, timeout time.Duration, client *internal.Client, moduleID, runID string) diag.Diagnostics {
+	if wait.disabled {
+		return nil
+	}
+
+	name := fmt.Sprintf("run %s of module %s", runID, moduleID)
+
+	refresh := func(ctx context.Context) (string, bool, error) {
+		run, err := getModuleRun(ctx, client, moduleID, runID)
+		if err != nil {
+			return "", false, errors.Wrapf(err, "could not query for %s", name)
+		}
+
+		if run == nil {
+			return "", false, errors.Errorf("%s not found", name)
+		}
+
+		return strings.ToLower(run.State), run.Finished, nil
+	}
+
+	finalState, diags := waitForRunState(ctx, name, refresh, nil, timeout)
+	if diags.HasError() {
+		return diags
+	}
+
+	return wait.outcome(ctx, name, finalState, "", nil)
+}
@@ -0,0 +1,68 @@
+package spacelift
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestModuleTestRunResource(t *testing.T) {
+	const resourceName = "spacelift_module_test_run.test"
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	testSteps(t, []resource.TestStep{
+		{
+			Config: fmt.Sprintf(`
+				resource "spacelift_module" "test" {
+					name           = "test-module-test-run-%s"
+					administrative = true
+					branch         = "module"
+					repository     = "terraform-bacon-tasty"
+				}
+
+				resource "spacelift_module_test_run" "test" {
+					module_id = spacelift_module.test.id
+
+					wait {
+						cancel_on_timeout = true
+					}
+				}
+			`, randomID),
+			ExpectError: regexp.MustCompile(`cancel_on_timeout`),
+		},
+		{
+			Config: fmt.Sprintf(`
+				resource "spacelift_module" "test" {
+					name           = "test-module-test-run-%s"
+					administrative = true
+					branch         = "module"
+					repository     = "terraform-bacon-tasty"
+				}
+
+				resource "spacelift_module_test_run" "test" {
+					module_id = spacelift_module.test.id
+
+					wait {
+						continue_on_state = ["finished", "failed"]
+					}
+				}
+			`, randomID),
+			Check: Resource(
+				resourceName,
+				Attribute("id", IsNotEmpty()),
+				Attribute("commit_sha", IsNotEmpty()),
+				Attribute("passed", IsNotEmpty()),
+				Attribute("test_cases.#", IsNotEmpty()),
+				Attribute("test_cases.0.name", IsNotEmpty()),
+				Attribute("test_cases.0.run_id", IsNotEmpty()),
+				Attribute("test_cases.0.state", IsNotEmpty()),
+			),
+		},
+	})
+}
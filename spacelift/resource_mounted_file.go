@@ -2,10 +2,17 @@ package spacelift
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
 	"github.com/shurcooL/graphql"
@@ -23,12 +30,20 @@ func resourceMountedFile() *schema.Resource {
 			"stack (`spacelift_stack`) or a module (`spacelift_module`). In principle, " +
 			"it's very similar to an environment variable (`spacelift_environment_variable`) " +
 			"except that the value is written to the filesystem rather than passed to " +
-			"the environment.",
+			"the environment. The content can be passed inline, read from a local " +
+			"file (`source_path`) or from a whole local directory (`source_dir`), " +
+			"in which case only the checksums are stored in the state.",
 
 		CreateContext: resourceMountedFileCreate,
 		ReadContext:   resourceMountedFileRead,
+		UpdateContext: resourceMountedFileUpdate,
 		DeleteContext: resourceMountedFileDelete,
 
+		CustomizeDiff: customdiff.All(
+			customizeDiffConfigChecksum("content"),
+			resourceMountedFileCustomizeDiff,
+		),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -39,13 +54,19 @@ func resourceMountedFile() *schema.Resource {
 				Description: "SHA-256 checksum of the value",
 				Computed:    true,
 			},
+			"checksums": {
+				Type:        schema.TypeMap,
+				Description: "SHA-256 checksums of the files mounted from `source_dir`, keyed by their path relative to it",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
 			"content": {
 				Type:             schema.TypeString,
 				Description:      "Content of the mounted file encoded using Base-64",
 				DiffSuppressFunc: suppressValueChange,
 				Sensitive:        true,
-				Required:         true,
-				ForceNew:         true,
+				Optional:         true,
+				ExactlyOneOf:     []string{"content", "source_path", "source_dir"},
 				ValidateDiagFunc: validations.DisallowEmptyString,
 			},
 			"context_id": {
@@ -67,6 +88,18 @@ func resourceMountedFile() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"source_dir": {
+				Type:             schema.TypeString,
+				Description:      "Path to a local directory whose files are mounted under `relative_path`, keeping their paths relative to it",
+				Optional:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"source_path": {
+				Type:             schema.TypeString,
+				Description:      "Path to a local file whose content is mounted. The file is read and hashed at plan time, and uploaded at apply time.",
+				Optional:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
 			"stack_id": {
 				Type:        schema.TypeString,
 				Description: "ID of the stack on which the mounted file is defined",
@@ -78,18 +111,26 @@ func resourceMountedFile() *schema.Resource {
 				Description: "Indicates whether the content can be read back outside a Run. Defaults to `true`.",
 				Optional:    true,
 				Default:     true,
-				ForceNew:    true,
 			},
 		},
 	}
 }
 
 func resourceMountedFileCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if _, ok := d.GetOk("source_dir"); ok {
+		return resourceMountedFileCreateDirectory(ctx, d, meta)
+	}
+
+	content, err := mountedFileContent(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	variables := map[string]interface{}{
 		"config": structs.ConfigInput{
 			ID:        toID(d.Get("relative_path")),
 			Type:      structs.ConfigType("FILE_MOUNT"),
-			Value:     toString(d.Get("content")),
+			Value:     graphql.String(content),
 			WriteOnly: graphql.Boolean(d.Get("write_only").(bool)),
 		},
 	}
@@ -117,6 +158,21 @@ func resourceMountedFileCreate(ctx context.Context, d *schema.ResourceData, meta
 	return resourceMountedFileCreateModule(ctx, d, meta.(*internal.Client), variables)
 }
 
+func resourceMountedFileCreateDirectory(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	resourceType, resourceID, err := configTarget(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := resourceMountedFileSyncDirectory(ctx, d, meta.(*internal.Client), resourceType, resourceID, nil, false); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", resourceType, resourceID, d.Get("relative_path")))
+
+	return resourceMountedFileRead(ctx, d, meta)
+}
+
 func resourceMountedFileCreateContext(ctx context.Context, d *schema.ResourceData, client *internal.Client, variables map[string]interface{}) diag.Diagnostics {
 	var mutation struct {
 		AddContextConfig structs.ConfigElement `graphql:"contextConfigAdd(context: $context, config: $config)"`
@@ -171,6 +227,10 @@ func resourceMountedFileRead(ctx context.Context, d *schema.ResourceData, meta i
 
 	resourceType, resourceID, relativePath := idParts[0], idParts[1], idParts[2]
 
+	if _, ok := d.GetOk("source_dir"); ok {
+		return resourceMountedFileReadDirectory(ctx, d, client, resourceType, resourceID, relativePath)
+	}
+
 	switch resourceType {
 	case "context":
 		element, err = resourceMountedFileReadContext(ctx, d, client, resourceID, relativePath)
@@ -195,6 +255,11 @@ func resourceMountedFileRead(ctx context.Context, d *schema.ResourceData, meta i
 	d.Set("relative_path", relativePath)
 	d.Set("write_only", element.WriteOnly)
 
+	// The content read from a local file is only tracked by its checksum.
+	if _, ok := d.GetOk("source_path"); ok {
+		return nil
+	}
+
 	if value := element.Value; value != nil {
 		d.Set("content", *value)
 	} else {
@@ -204,6 +269,45 @@ func resourceMountedFileRead(ctx context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+func resourceMountedFileReadDirectory(ctx context.Context, d *schema.ResourceData, client *internal.Client, resourceType, resourceID, prefix string) diag.Diagnostics {
+	elements, err := listConfigElements(ctx, client, resourceType, resourceID, structs.ConfigType("FILE_MOUNT"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if elements == nil {
+		d.SetId("")
+		return nil
+	}
+
+	known := d.Get("checksums").(map[string]interface{})
+	checksums := make(map[string]interface{})
+
+	for file := range known {
+		element, ok := elements[path.Join(prefix, file)]
+		if !ok {
+			continue
+		}
+
+		checksums[file] = element.Checksum
+		d.Set("write_only", element.WriteOnly)
+	}
+
+	if len(known) > 0 && len(checksums) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set(resourceType+"_id", resourceID)
+	d.Set("relative_path", prefix)
+
+	if err := d.Set("checksums", checksums); err != nil {
+		return diag.Errorf("could not set checksums: %v", err)
+	}
+
+	return nil
+}
+
 func resourceMountedFileReadContext(ctx context.Context, d *schema.ResourceData, client *internal.Client, contextID, relativePath string) (*structs.ConfigElement, error) {
 	var query struct {
 		Context *struct {
@@ -270,25 +374,66 @@ func resourceMountedFileReadStack(ctx context.Context, d *schema.ResourceData, c
 	return query.Stack.ConfigElement, nil
 }
 
-func resourceMountedFileDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceMountedFileUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	idParts := strings.SplitN(d.Id(), "/", 3)
 	if len(idParts) != 3 {
 		return diag.Errorf("unexpected resource ID: %s", d.Id())
 	}
 
 	client := meta.(*internal.Client)
-	var err error
 
-	switch resourceType, contextID, fileID := idParts[0], idParts[1], idParts[2]; resourceType {
-	case "context":
-		err = resourceMountedFileDeleteContext(ctx, d, client, toID(contextID), toID(fileID))
-	case "stack", "module":
-		err = resourceMountedFileDeleteStack(ctx, d, client, toID(contextID), toID(fileID))
-	default:
-		return diag.Errorf("unexpected resource type: %s", resourceType)
+	if _, ok := d.GetOk("source_dir"); ok {
+		previous, _ := d.GetChange("checksums")
+
+		// Changing the secrecy means all the files have to be written again.
+		if diags := resourceMountedFileSyncDirectory(ctx, d, client, idParts[0], idParts[1], previous.(map[string]interface{}), d.HasChange("write_only")); diags.HasError() {
+			return diags
+		}
+
+		return resourceMountedFileRead(ctx, d, meta)
 	}
 
+	content, err := mountedFileContent(d)
 	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config := structs.ConfigInput{
+		ID:        toID(idParts[2]),
+		Type:      structs.ConfigType("FILE_MOUNT"),
+		Value:     graphql.String(content),
+		WriteOnly: graphql.Boolean(d.Get("write_only").(bool)),
+	}
+
+	if err := addConfigElement(ctx, client, idParts[0], idParts[1], config); err != nil {
+		return diag.Errorf("could not update mounted file: %v", internal.FromSpaceliftError(err))
+	}
+
+	return resourceMountedFileRead(ctx, d, meta)
+}
+
+func resourceMountedFileDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	idParts := strings.SplitN(d.Id(), "/", 3)
+	if len(idParts) != 3 {
+		return diag.Errorf("unexpected resource ID: %s", d.Id())
+	}
+
+	client := meta.(*internal.Client)
+	resourceType, resourceID, relativePath := idParts[0], idParts[1], idParts[2]
+
+	if _, ok := d.GetOk("source_dir"); ok {
+		for file := range d.Get("checksums").(map[string]interface{}) {
+			if err := resourceMountedFileDeleteFile(ctx, d, client, resourceType, resourceID, path.Join(relativePath, file)); err != nil {
+				return diag.Errorf("could not delete mounted file %s: %v", file, internal.FromSpaceliftError(err))
+			}
+		}
+
+		d.SetId("")
+
+		return nil
+	}
+
+	if err := resourceMountedFileDeleteFile(ctx, d, client, resourceType, resourceID, relativePath); err != nil {
 		return diag.Errorf("could not delete mounted file: %v", internal.FromSpaceliftError(err))
 	}
 
@@ -297,6 +442,17 @@ func resourceMountedFileDelete(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+func resourceMountedFileDeleteFile(ctx context.Context, d *schema.ResourceData, client *internal.Client, resourceType, resourceID, fileID string) error {
+	switch resourceType {
+	case "context":
+		return resourceMountedFileDeleteContext(ctx, d, client, toID(resourceID), toID(fileID))
+	case "stack", "module":
+		return resourceMountedFileDeleteStack(ctx, d, client, toID(resourceID), toID(fileID))
+	default:
+		return errors.Errorf("unexpected resource type: %s", resourceType)
+	}
+}
+
 func resourceMountedFileDeleteContext(ctx context.Context, d *schema.ResourceData, client *internal.Client, context graphql.ID, id graphql.ID) error {
 	var mutation struct {
 		DeleteContextConfig *structs.ConfigElement `graphql:"contextConfigDelete(context: $context, id: $id)"`
@@ -312,3 +468,155 @@ func resourceMountedFileDeleteStack(ctx context.Context, d *schema.ResourceData,
 
 	return client.Mutate(ctx, "MountedFileDeleteStack", &mutation, map[string]interface{}{"stack": stack, "id": id})
 }
+
+func resourceMountedFileCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Mounting a directory creates one config element per file, so switching
+	// to or from it means starting over.
+	if oldDir, newDir := d.GetChange("source_dir"); (oldDir.(string) == "") != (newDir.(string) == "") && d.Id() != "" {
+		if err := d.ForceNew("source_dir"); err != nil {
+			return err
+		}
+	}
+
+	if sourcePath := d.Get("source_path").(string); sourcePath != "" {
+		_, checksum, err := readMountedFileSource(sourcePath)
+		if err != nil {
+			return err
+		}
+
+		if checksum != d.Get("checksum").(string) {
+			return d.SetNew("checksum", checksum)
+		}
+
+		return nil
+	}
+
+	if sourceDir := d.Get("source_dir").(string); sourceDir != "" {
+		checksums, err := readMountedFileSourceDir(sourceDir)
+		if err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(checksums, d.Get("checksums")) {
+			return d.SetNew("checksums", checksums)
+		}
+	}
+
+	return nil
+}
+
+// mountedFileContent returns the Base-64 encoded content of the mounted file,
+// reading it from source_path if set.
+func mountedFileContent(d *schema.ResourceData) (string, error) {
+	sourcePath, ok := d.GetOk("source_path")
+	if !ok {
+		return configuredValue(d, "content"), nil
+	}
+
+	content, checksum, err := readMountedFileSource(sourcePath.(string))
+	if err != nil {
+		return "", err
+	}
+
+	if planned := d.Get("checksum").(string); planned != "" && planned != checksum {
+		return "", errors.Errorf("%s changed between plan and apply", sourcePath)
+	}
+
+	return content, nil
+}
+
+// readMountedFileSource returns the Base-64 encoded content of a local file
+// along with the checksum Spacelift will report for it.
+func readMountedFileSource(sourcePath string) (content, checksum string, err error) {
+	data, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "could not read %s", sourcePath)
+	}
+
+	content = base64.StdEncoding.EncodeToString(data)
+
+	return content, configChecksum(content), nil
+}
+
+// readMountedFileSourceDir returns the checksums of all the files in a local
+// directory tree, keyed by their slash-separated path relative to it.
+func readMountedFileSourceDir(sourceDir string) (map[string]interface{}, error) {
+	checksums := make(map[string]interface{})
+
+	err := filepath.WalkDir(sourceDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(sourceDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		_, checksum, err := readMountedFileSource(filePath)
+		if err != nil {
+			return err
+		}
+
+		checksums[filepath.ToSlash(relativePath)] = checksum
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read directory %s", sourceDir)
+	}
+
+	return checksums, nil
+}
+
+// resourceMountedFileSyncDirectory uploads the files from source_dir whose
+// checksum differs from the previous one, or all of them if rewrite is set,
+// and deletes the ones which are gone.
+func resourceMountedFileSyncDirectory(ctx context.Context, d *schema.ResourceData, client *internal.Client, resourceType, resourceID string, previous map[string]interface{}, rewrite bool) diag.Diagnostics {
+	sourceDir := d.Get("source_dir").(string)
+	prefix := d.Get("relative_path").(string)
+
+	checksums, err := readMountedFileSourceDir(sourceDir)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if planned := d.Get("checksums").(map[string]interface{}); len(planned) > 0 && !reflect.DeepEqual(checksums, planned) {
+		return diag.Errorf("%s changed between plan and apply", sourceDir)
+	}
+
+	for file, checksum := range checksums {
+		if !rewrite && previous[file] == checksum {
+			continue
+		}
+
+		content, _, err := readMountedFileSource(filepath.Join(sourceDir, filepath.FromSlash(file)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		config := structs.ConfigInput{
+			ID:        toID(path.Join(prefix, file)),
+			Type:      structs.ConfigType("FILE_MOUNT"),
+			Value:     graphql.String(content),
+			WriteOnly: graphql.Boolean(d.Get("write_only").(bool)),
+		}
+
+		if err := addConfigElement(ctx, client, resourceType, resourceID, config); err != nil {
+			return diag.Errorf("could not upload mounted file %s: %v", file, internal.FromSpaceliftError(err))
+		}
+	}
+
+	for file := range previous {
+		if _, ok := checksums[file]; ok {
+			continue
+		}
+
+		if err := resourceMountedFileDeleteFile(ctx, d, client, resourceType, resourceID, path.Join(prefix, file)); err != nil {
+			return diag.Errorf("could not delete mounted file %s: %v", file, internal.FromSpaceliftError(err))
+		}
+	}
+
+	return nil
+}
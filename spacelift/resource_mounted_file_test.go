@@ -2,6 +2,8 @@ package spacelift
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -16,7 +18,7 @@ func TestMountedFileResource(t *testing.T) {
 	t.Run("with a context", func(t *testing.T) {
 		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
 
-		config := func(writeOnly bool) string {
+		config := func(content string, writeOnly bool) string {
 			return fmt.Sprintf(`
 				resource "spacelift_context" "test" {
 					name = "My first context %s"
@@ -24,16 +26,16 @@ func TestMountedFileResource(t *testing.T) {
 
 				resource "spacelift_mounted_file" "test" {
 					context_id    = spacelift_context.test.id
-					content       = base64encode("bacon is tasty")
+					content       = base64encode("%s")
 					relative_path = "bacon.txt"
 					write_only    = %t
 				}
-			`, randomID, writeOnly)
+			`, randomID, content, writeOnly)
 		}
 
 		testSteps(t, []resource.TestStep{
 			{
-				Config: config(true),
+				Config: config("bacon is tasty", true),
 				Check: Resource(
 					resourceName,
 					Attribute("id", IsNotEmpty()),
@@ -49,8 +51,22 @@ func TestMountedFileResource(t *testing.T) {
 				ImportStateVerify: true,
 			},
 			{
-				Config: config(false),
-				Check:  Resource(resourceName, Attribute("write_only", Equals("false"))),
+				Config: config("bacon is tasty", false),
+				Check: Resource(
+					resourceName,
+					Attribute("checksum", Equals("fb13e7977b7548a324b598e155b5b5ba3dcca2dad5789abe1411a88fa544be9b")),
+					Attribute("content", Equals("YmFjb24gaXMgdGFzdHk=")),
+					Attribute("write_only", Equals("false")),
+				),
+			},
+			{
+				Config: config("bacon is crispy", true),
+				Check: Resource(
+					resourceName,
+					Attribute("checksum", Equals("4334e82481e24d12c30d8ef68f2cea8b56512df4ea93ef049e98f54389edefd7")),
+					Attribute("content", Equals("4334e82481e24d12c30d8ef68f2cea8b56512df4ea93ef049e98f54389edefd7")),
+					Attribute("write_only", Equals("true")),
+				),
 			},
 		})
 	})
@@ -118,4 +134,112 @@ func TestMountedFileResource(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("with a local file", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		sourcePath := filepath.Join(t.TempDir(), "bacon.txt")
+
+		config := fmt.Sprintf(`
+			resource "spacelift_context" "test" {
+				name = "My first context %s"
+			}
+
+			resource "spacelift_mounted_file" "test" {
+				context_id    = spacelift_context.test.id
+				relative_path = "bacon.txt"
+				source_path   = %q
+			}
+		`, randomID, sourcePath)
+
+		testSteps(t, []resource.TestStep{
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(sourcePath, []byte("bacon is tasty"), 0600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: Resource(
+					resourceName,
+					Attribute("checksum", Equals("fb13e7977b7548a324b598e155b5b5ba3dcca2dad5789abe1411a88fa544be9b")),
+					Attribute("source_path", Equals(sourcePath)),
+					AttributeNotPresent("content"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(sourcePath, []byte("bacon is crispy"), 0600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: Resource(
+					resourceName,
+					Attribute("checksum", Equals("4334e82481e24d12c30d8ef68f2cea8b56512df4ea93ef049e98f54389edefd7")),
+					AttributeNotPresent("content"),
+				),
+			},
+		})
+	})
+
+	t.Run("with a local directory", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		sourceDir := t.TempDir()
+
+		writeFile := func(name, content string) {
+			filePath := filepath.Join(sourceDir, filepath.FromSlash(name))
+
+			if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		config := fmt.Sprintf(`
+			resource "spacelift_context" "test" {
+				name = "My first context %s"
+			}
+
+			resource "spacelift_mounted_file" "test" {
+				context_id    = spacelift_context.test.id
+				relative_path = "food"
+				source_dir    = %q
+			}
+		`, randomID, sourceDir)
+
+		testSteps(t, []resource.TestStep{
+			{
+				PreConfig: func() {
+					writeFile("bacon.txt", "bacon is tasty")
+					writeFile("vegetables/cabbage.txt", "cabbage is healthy")
+				},
+				Config: config,
+				Check: Resource(
+					resourceName,
+					Attribute("checksums.%", Equals("2")),
+					Attribute("checksums.bacon.txt", Equals("fb13e7977b7548a324b598e155b5b5ba3dcca2dad5789abe1411a88fa544be9b")),
+					Attribute("checksums.vegetables/cabbage.txt", IsNotEmpty()),
+					AttributeNotPresent("content"),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeFile("bacon.txt", "bacon is crispy")
+
+					if err := os.RemoveAll(filepath.Join(sourceDir, "vegetables")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: Resource(
+					resourceName,
+					Attribute("checksums.%", Equals("1")),
+					Attribute("checksums.bacon.txt", Equals("4334e82481e24d12c30d8ef68f2cea8b56512df4ea93ef049e98f54389edefd7")),
+				),
+			},
+		})
+	})
 }
@@ -2,6 +2,7 @@ package spacelift
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -52,6 +53,7 @@ func resourcePolicy() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"adopt_existing": adoptExistingSchema("policy"),
 			"name": {
 				Type:             schema.TypeString,
 				Description:      "Name of the policy - should be unique in one account",
@@ -135,7 +137,15 @@ func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	variables := map[string]interface{}{"input": input}
 
 	if err := meta.(*internal.Client).Mutate(ctx, "PolicyCreateV2", &mutation, variables); err != nil {
-		return diag.Errorf("could not create policy %v: %v", toString(d.Get("name")), internal.FromSpaceliftError(err))
+		diags := diag.Errorf("could not create policy %v: %v", toString(d.Get("name")), internal.FromSpaceliftError(err))
+
+		if d.Get("adopt_existing").(bool) {
+			slug, _ := computeSlug(d.Get("name").(string))
+			lookup := lookupExistingPolicy(d.Get("type").(string))
+			return adoptExisting(ctx, d, meta, "policy", slug, err, diags, lookup, resourcePolicyUpdate)
+		}
+
+		return diags
 	}
 
 	d.SetId(mutation.CreatePolicy.ID)
@@ -143,6 +153,32 @@ func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	return resourcePolicyRead(ctx, d, meta)
 }
 
+// lookupExistingPolicy only allows adopting policies of the given type, which
+// can't be changed.
+func lookupExistingPolicy(policyType string) adoptLookupFunc {
+	return func(ctx context.Context, client *internal.Client, id string) (string, bool, error) {
+		var query struct {
+			Policy *structs.Policy `graphql:"policy(id: $id)"`
+		}
+
+		variables := map[string]interface{}{"id": graphql.ID(id)}
+
+		if err := client.Query(ctx, "PolicyRead", &query, variables); err != nil {
+			return "", false, err
+		}
+
+		if query.Policy == nil {
+			return "", false, nil
+		}
+
+		if actual := query.Policy.Type; actual != policyType && typeNameReplacements[actual] != policyType {
+			return "", false, fmt.Errorf("policy has type %s instead of %s", actual, policyType)
+		}
+
+		return query.Policy.Space, true, nil
+	}
+}
+
 func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var query struct {
 		Policy *structs.Policy `graphql:"policy(id: $id)"`
@@ -88,6 +88,42 @@ func TestPolicyResource(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("adopts an existing policy", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		existing := fmt.Sprintf(`
+			resource "spacelift_policy" "existing" {
+				name     = "Adopted policy %s"
+				body     = "package spacelift"
+				type     = "PLAN"
+				space_id = "root"
+			}
+		`, randomID)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: existing,
+			},
+			{
+				Config: existing + fmt.Sprintf(`
+					resource "spacelift_policy" "test" {
+						name           = "Adopted policy %s"
+						body           = "package spacelift"
+						type           = "PLAN"
+						space_id       = "root"
+						adopt_existing = true
+						depends_on     = [spacelift_policy.existing]
+					}
+				`, randomID),
+				Check: Resource(
+					resourceName,
+					Attribute("id", Equals(fmt.Sprintf("adopted-policy-%s", randomID))),
+					Attribute("adopt_existing", Equals("true")),
+				),
+			},
+		})
+	})
 }
 
 func TestPolicyResourceSpace(t *testing.T) {
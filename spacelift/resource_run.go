@@ -2,6 +2,7 @@ package spacelift
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"strings"
@@ -9,13 +10,15 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 	"github.com/shurcooL/graphql"
 
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/waiter"
 )
 
 func resourceRun() *schema.Resource {
@@ -25,10 +28,16 @@ func resourceRun() *schema.Resource {
 			"to arbitrary changes in the keepers section.",
 
 		CreateContext: resourceRunCreate,
-		ReadContext:   schema.NoopContext,
+		ReadContext:   resourceRunRead,
 		Delete:        schema.RemoveFromState,
 		UpdateContext: schema.NoopContext,
 
+		CustomizeDiff: customizeDiffLogsRequireWait,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRunImport,
+		},
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 		},
@@ -67,32 +76,134 @@ func resourceRun() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"wait": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				Description: "Wait for the run to finish",
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"disabled": {
-							Type:        schema.TypeBool,
-							Description: "Whether waiting for a job is disabled or not. Default: `false`",
-							Optional:    true,
-							Default:     false,
-						},
-						"continue_on_state": {
-							Type: schema.TypeSet,
-							Elem: &schema.Schema{
-								Type: schema.TypeString,
+			"state": {
+				Description: "The last known state of the run, lowercased (e.g. `finished`, `failed`, `unconfirmed`).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"finished": {
+				Description: "Whether the run has reached a terminal state.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"commit": {
+				Description: "The SHA of the commit the run was executed for.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"triggered_by": {
+				Description: "The user or entity that triggered the run.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created_at": {
+				Description: "Unix timestamp at which the run was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"finished_at": {
+				Description: "Unix timestamp at which the run finished. Not set while the run is in progress.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"resources_added": {
+				Description: "Number of resources the run planned to add.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"resources_changed": {
+				Description: "Number of resources the run planned to change.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"resources_deleted": {
+				Description: "Number of resources the run planned to delete.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"wait": waitConfigurationSchema(),
+			"logs": logsConfigurationSchema(),
+		},
+	}
+}
+
+func waitConfigurationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Wait for the run to finish",
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"disabled": {
+					Type:        schema.TypeBool,
+					Description: "Whether waiting for a job is disabled or not. Default: `false`",
+					Optional:    true,
+					Default:     false,
+				},
+				"continue_on_state": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Description: "Continue on the specified states of a finished run. If not specified, the default is `[ 'finished' ]`. You can use following states: `applying`, `canceled`, `confirmed`, `destroying`, `discarded`, `failed`, `finished`, `initializing`, `pending_review`, `performing`, `planning`, `preparing_apply`, `preparing_replan`, `preparing`, `queued`, `ready`, `replan_requested`, `skipped`, `stopped`, `unconfirmed`.",
+					Optional:    true,
+				},
+				"continue_on_timeout": {
+					Type:        schema.TypeBool,
+					Description: "Continue if run timed out, i.e. did not reach any defined end state in time. Default: `false`",
+					Optional:    true,
+					Default:     false,
+				},
+				"cancel_on_interrupt": {
+					Type:        schema.TypeBool,
+					Description: "Stop the run in Spacelift if Terraform is interrupted (e.g. Ctrl-C) while waiting for it. Default: `false`",
+					Optional:    true,
+					Default:     false,
+				},
+				"cancel_on_timeout": {
+					Type:        schema.TypeBool,
+					Description: "Stop the run in Spacelift if it did not reach any defined end state in time. Default: `false`",
+					Optional:    true,
+					Default:     false,
+				},
+				"on_unconfirmed": {
+					Type:        schema.TypeList,
+					Description: "What to do when the run reaches the `unconfirmed` state. If not specified, waiting stops at `unconfirmed`.",
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"action": {
+								Type:        schema.TypeString,
+								Description: "Action to take on an unconfirmed run: `confirm` it and keep waiting, `discard` it and keep waiting, or `stop` waiting. When confirming or discarding, make sure the resulting state (e.g. `discarded`) is listed in `continue_on_state`.",
+								Required:    true,
+								ValidateFunc: validation.StringInSlice(
+									[]string{unconfirmedActionConfirm, unconfirmedActionDiscard, unconfirmedActionStop},
+									false,
+								),
+							},
+							"max_resources_added": {
+								Type:         schema.TypeInt,
+								Description:  "Only take the action if the plan adds at most this many resources. Otherwise, or if the number is unknown, the run is left unconfirmed and waiting stops. Default: `-1` (no limit)",
+								Optional:     true,
+								Default:      -1,
+								ValidateFunc: validation.IntAtLeast(-1),
+							},
+							"max_resources_changed": {
+								Type:         schema.TypeInt,
+								Description:  "Only take the action if the plan changes at most this many resources. Otherwise, or if the number is unknown, the run is left unconfirmed and waiting stops. Default: `-1` (no limit)",
+								Optional:     true,
+								Default:      -1,
+								ValidateFunc: validation.IntAtLeast(-1),
+							},
+							"max_resources_deleted": {
+								Type:         schema.TypeInt,
+								Description:  "Only take the action if the plan deletes at most this many resources, e.g. `0` to only confirm plans without deletions. Otherwise, or if the number is unknown, the run is left unconfirmed and waiting stops. Default: `-1` (no limit)",
+								Optional:     true,
+								Default:      -1,
+								ValidateFunc: validation.IntAtLeast(-1),
 							},
-							Description: "Continue on the specified states of a finished run. If not specified, the default is `[ 'finished' ]`. You can use following states: `applying`, `canceled`, `confirmed`, `destroying`, `discarded`, `failed`, `finished`, `initializing`, `pending_review`, `performing`, `planning`, `preparing_apply`, `preparing_replan`, `preparing`, `queued`, `ready`, `replan_requested`, `skipped`, `stopped`, `unconfirmed`.",
-							Optional:    true,
-						},
-						"continue_on_timeout": {
-							Type:        schema.TypeBool,
-							Description: "Continue if run timed out, i.e. did not reach any defined end state in time. Default: `false`",
-							Optional:    true,
-							Default:     false,
 						},
 					},
 				},
@@ -101,10 +212,26 @@ func resourceRun() *schema.Resource {
 	}
 }
 
+const (
+	unconfirmedActionConfirm = "confirm"
+	unconfirmedActionDiscard = "discard"
+	unconfirmedActionStop    = "stop"
+)
+
 type waitConfiguration struct {
 	disabled          bool
 	continueOnState   []string
 	continueOnTimeout bool
+	cancelOnInterrupt bool
+	cancelOnTimeout   bool
+	onUnconfirmed     *unconfirmedConfiguration
+}
+
+type unconfirmedConfiguration struct {
+	action              string
+	maxResourcesAdded   int
+	maxResourcesChanged int
+	maxResourcesDeleted int
 }
 
 func expandWaitConfiguration(input []interface{}) *waitConfiguration {
@@ -116,6 +243,8 @@ func expandWaitConfiguration(input []interface{}) *waitConfiguration {
 		disabled:          v["disabled"].(bool),
 		continueOnState:   []string{},
 		continueOnTimeout: v["continue_on_timeout"].(bool),
+		cancelOnInterrupt: v["cancel_on_interrupt"].(bool),
+		cancelOnTimeout:   v["cancel_on_timeout"].(bool),
 	}
 
 	if v, ok := v["continue_on_state"]; ok {
@@ -130,68 +259,97 @@ func expandWaitConfiguration(input []interface{}) *waitConfiguration {
 	if len(cfg.continueOnState) == 0 {
 		cfg.continueOnState = append(cfg.continueOnState, "finished")
 	}
+
+	if v, ok := v["on_unconfirmed"]; ok {
+		cfg.onUnconfirmed = expandUnconfirmedConfiguration(v.([]interface{}))
+	}
+
 	return cfg
 }
 
-func (wait *waitConfiguration) Wait(ctx context.Context, d *schema.ResourceData, client *internal.Client, stackID, mutationID string) diag.Diagnostics {
-	if wait.disabled {
+func expandUnconfirmedConfiguration(input []interface{}) *unconfirmedConfiguration {
+	if len(input) == 0 || input[0] == nil {
 		return nil
 	}
+	v := input[0].(map[string]interface{})
+	return &unconfirmedConfiguration{
+		action:              v["action"].(string),
+		maxResourcesAdded:   v["max_resources_added"].(int),
+		maxResourcesChanged: v["max_resources_changed"].(int),
+		maxResourcesDeleted: v["max_resources_deleted"].(int),
+	}
+}
 
-	stateConf := &retry.StateChangeConf{
-		ContinuousTargetOccurence: 1,
-		Delay:                     10 * time.Second,
-		MinTimeout:                10 * time.Second,
-		Pending: []string{
-			"running",
-		},
-		Target: []string{
-			"finished",
-			"unconfirmed", // Let's treat unconfirmed as the target state.
-			// It's not finished, but we don't want to wait for it because it requires confirmation from someone.
-		},
-		Refresh: checkStackStatusFunc(ctx, client, stackID, mutationID),
-		Timeout: d.Timeout(schema.TimeoutCreate),
+func (wait *waitConfiguration) Wait(ctx context.Context, timeout time.Duration, client *internal.Client, stackID, mutationID string) diag.Diagnostics {
+	if wait.disabled {
+		return nil
 	}
 
-	finalState, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		if timeoutErr, ok := internal.AsError[*retry.TimeoutError](err); ok {
-			tflog.Debug(ctx, "received retry.TimeoutError from WaitForStateContext", map[string]any{
-				"stackID":       stackID,
-				"runID":         mutationID,
-				"lastState":     timeoutErr.LastState,
-				"expectedState": timeoutErr.ExpectedState,
-			})
-			finalState = "__timeout__"
-		} else if err == context.DeadlineExceeded {
-			tflog.Debug(ctx, "received context.DeadlineExceeded from WaitForStateContext", map[string]any{
-				"stackID": stackID,
-				"runID":   mutationID,
-			})
-			finalState = "__timeout__"
-		} else {
-			return diag.Errorf("failed waiting for run %s on stack %s to finish. error(%T): %+v ", mutationID, stackID, err, err)
+	deadline := time.Now().Add(timeout)
+
+	var finalState, unconfirmedReason string
+	for {
+		var diags diag.Diagnostics
+		if finalState, diags = wait.waitForState(ctx, client, stackID, mutationID, time.Until(deadline)); diags.HasError() {
+			return diags
+		}
+
+		if finalState != "unconfirmed" || wait.onUnconfirmed == nil {
+			break
+		}
+
+		acted, reason, err := wait.onUnconfirmed.handle(ctx, client, stackID, mutationID)
+		if err != nil {
+			return diag.Errorf("could not handle unconfirmed run %s on stack %s: %v", mutationID, stackID, internal.FromSpaceliftError(err))
+		}
+		if !acted {
+			unconfirmedReason = reason
+			break
 		}
 	}
 
-	switch finalState.(string) {
+	return wait.outcome(ctx, fmt.Sprintf("run %s on stack %s", mutationID, stackID), finalState, unconfirmedReason, func() (string, error) {
+		return cancelRun(ctx, client, stackID, mutationID)
+	})
+}
+
+// outcome turns the state a run ended up in while waiting for it into
+// diagnostics, stopping the run if configured to. If cancel is nil, the run
+// can't be stopped.
+func (wait *waitConfiguration) outcome(ctx context.Context, name, finalState, unconfirmedReason string, cancel func() (string, error)) diag.Diagnostics {
+	switch finalState {
+	case "__interrupted__":
+		if !wait.cancelOnInterrupt || cancel == nil {
+			return diag.Errorf("failed waiting for %s to finish. error(%T): %+v ", name, ctx.Err(), ctx.Err())
+		}
+		state, err := cancel()
+		if err != nil {
+			return diag.Errorf("%s was interrupted, but could not be stopped: %v", name, internal.FromSpaceliftError(err))
+		}
+		return diag.Errorf("%s was interrupted and has been stopped, final state: %s", name, state)
 	case "__timeout__":
+		if wait.cancelOnTimeout && cancel != nil {
+			state, err := cancel()
+			if err != nil {
+				return diag.Errorf("%s has timed out, but could not be stopped: %v", name, internal.FromSpaceliftError(err))
+			}
+			if !wait.continueOnTimeout {
+				return diag.Errorf("%s has timed out and has been stopped, final state: %s", name, state)
+			}
+		}
 		if !wait.continueOnTimeout {
-			return diag.Errorf("run %s on stack %s has timed out", mutationID, stackID)
+			return diag.Errorf("%s has timed out", name)
 		}
-		tflog.Info(ctx, "run timed out but continue_on_timeout=true",
-			map[string]any{
-				"stackID": stackID,
-				"runID":   mutationID,
-			})
+		tflog.Info(ctx, "run timed out but continue_on_timeout=true", map[string]any{"run": name})
 	default:
-		if !slices.Contains[[]string](wait.continueOnState, finalState.(string)) {
-			return diag.Errorf("run %s on stack %s has ended with status %s. expected %v", mutationID, stackID, finalState, wait.continueOnState)
+		if !slices.Contains[[]string](wait.continueOnState, finalState) {
+			if unconfirmedReason != "" {
+				return diag.Errorf("%s was left unconfirmed: %s. expected %v", name, unconfirmedReason, wait.continueOnState)
+			}
+			return diag.Errorf("%s has ended with status %s. expected %v", name, finalState, wait.continueOnState)
 		}
 		tflog.Debug(ctx, "run finished", map[string]any{
-			"stackID":    stackID,
-			"runID":      mutationID,
+			"run":        name,
 			"finalState": finalState,
 		})
 	}
@@ -199,6 +357,230 @@ func (wait *waitConfiguration) Wait(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
+// waitForState waits until the run is either finished or unconfirmed, and
+// returns its state. If the run does not get there in time, the returned state
+// is "__timeout__", and if the context gets cancelled, "__interrupted__".
+func (wait *waitConfiguration) waitForState(ctx context.Context, client *internal.Client, stackID, mutationID string, timeout time.Duration) (string, diag.Diagnostics) {
+	return waitForRunState(
+		ctx,
+		fmt.Sprintf("run %s on stack %s", mutationID, stackID),
+		checkStackStatusFunc(client, stackID, mutationID),
+		watchStackRunStateFunc(client, stackID, mutationID),
+		timeout,
+	)
+}
+
+// waitForRunState waits for a run using the given refresh and, optionally,
+// watch functions. See waitForState for the returned state.
+func waitForRunState(ctx context.Context, name string, refresh waiter.RefreshFunc, watch waiter.WatchFunc, timeout time.Duration) (string, diag.Diagnostics) {
+	if timeout <= 0 {
+		return "__timeout__", nil
+	}
+
+	w := &waiter.Waiter{
+		Name:    name,
+		Refresh: refresh,
+		Watch:   watch,
+		// Let's treat unconfirmed as a terminal state. It's not finished, but
+		// it requires confirmation from someone, which may be us if
+		// on_unconfirmed is configured.
+		Terminal:    []string{"unconfirmed"},
+		Timeout:     timeout,
+		Delay:       10 * time.Second,
+		MinInterval: 10 * time.Second,
+		MaxInterval: time.Minute,
+	}
+
+	finalState, err := w.Wait(ctx)
+	switch {
+	case err == nil:
+		return finalState, nil
+	case internal.IsErrorType[*waiter.TimeoutError](err), errors.Is(err, context.DeadlineExceeded):
+		tflog.Debug(ctx, "timed out waiting for run", map[string]any{
+			"run":       name,
+			"lastState": finalState,
+		})
+		return "__timeout__", nil
+	case errors.Is(err, context.Canceled):
+		tflog.Debug(ctx, "interrupted while waiting for run", map[string]any{
+			"run":       name,
+			"lastState": finalState,
+		})
+		return "__interrupted__", nil
+	default:
+		return "", diag.Errorf("failed waiting for %s to finish. error(%T): %+v ", name, err, err)
+	}
+}
+
+// handle takes the configured action on an unconfirmed run. It reports whether
+// the action has been taken and waiting should go on, and if not, why.
+func (cfg *unconfirmedConfiguration) handle(ctx context.Context, client *internal.Client, stackID, runID string) (bool, string, error) {
+	if cfg.action == unconfirmedActionStop {
+		return false, "on_unconfirmed action is stop", nil
+	}
+
+	run, err := getRun(ctx, client, stackID, runID)
+	if err != nil {
+		return false, "", err
+	}
+	if run == nil {
+		return false, "", errors.Errorf("run %s not found", runID)
+	}
+
+	if reason := cfg.violatedGuard(run); reason != "" {
+		tflog.Info(ctx, "leaving run unconfirmed", map[string]any{
+			"stackID": stackID,
+			"runID":   runID,
+			"reason":  reason,
+		})
+		return false, reason, nil
+	}
+
+	variables := map[string]interface{}{
+		"stack": toID(stackID),
+		"run":   toID(runID),
+	}
+
+	switch cfg.action {
+	case unconfirmedActionConfirm:
+		var mutation struct {
+			Run struct {
+				ID string `graphql:"id"`
+			} `graphql:"runConfirm(stack: $stack, run: $run)"`
+		}
+		err = client.Mutate(ctx, "RunConfirm", &mutation, variables)
+	case unconfirmedActionDiscard:
+		var mutation struct {
+			Run struct {
+				ID string `graphql:"id"`
+			} `graphql:"runDiscard(stack: $stack, run: $run)"`
+		}
+		err = client.Mutate(ctx, "RunDiscard", &mutation, variables)
+	}
+	if err != nil {
+		return false, "", err
+	}
+
+	tflog.Info(ctx, "handled unconfirmed run", map[string]any{
+		"stackID": stackID,
+		"runID":   runID,
+		"action":  cfg.action,
+	})
+
+	return true, "", nil
+}
+
+// cancelRun stops a run that is still in progress and returns its final state.
+// Since it's meant to be called when the caller's context is already cancelled
+// or expired, it detaches from its cancellation and uses a short timeout instead.
+func cancelRun(ctx context.Context, client *internal.Client, stackID, runID string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Minute)
+	defer cancel()
+
+	state, finished, err := getStackRunStateByID(ctx, client, stackID, runID)
+	if err != nil {
+		return "", err
+	}
+	if finished {
+		return state, nil
+	}
+
+	variables := map[string]interface{}{
+		"stack": toID(stackID),
+		"run":   toID(runID),
+	}
+
+	var mutationName string
+	var mutation interface{}
+
+	switch state {
+	case "queued", "ready":
+		// The run has not started yet, so there's nothing to stop.
+		mutationName, mutation = "RunCancel", &struct {
+			Run struct {
+				ID string `graphql:"id"`
+			} `graphql:"runCancel(stack: $stack, run: $run)"`
+		}{}
+	case "unconfirmed":
+		mutationName, mutation = "RunDiscard", &struct {
+			Run struct {
+				ID string `graphql:"id"`
+			} `graphql:"runDiscard(stack: $stack, run: $run)"`
+		}{}
+	default:
+		mutationName, mutation = "RunStop", &struct {
+			Run struct {
+				ID string `graphql:"id"`
+			} `graphql:"runStop(stack: $stack, run: $run)"`
+		}{}
+	}
+
+	if err := client.Mutate(ctx, mutationName, mutation, variables); err != nil {
+		return "", err
+	}
+
+	tflog.Info(ctx, "stopped run", map[string]any{
+		"stackID":  stackID,
+		"runID":    runID,
+		"mutation": mutationName,
+	})
+
+	w := &waiter.Waiter{
+		Name:        fmt.Sprintf("run %s on stack %s to stop", runID, stackID),
+		Refresh:     checkStackStatusFunc(client, stackID, runID),
+		Watch:       watchStackRunStateFunc(client, stackID, runID),
+		Delay:       5 * time.Second,
+		MinInterval: 5 * time.Second,
+		MaxInterval: 15 * time.Second,
+	}
+
+	finalState, err := w.Wait(ctx)
+	if err != nil && ctx.Err() == nil {
+		return "", err
+	}
+
+	// The run was told to stop, but we can't wait for it forever, so we
+	// settle for the last state we've seen.
+	if finalState == "" {
+		finalState = state
+	}
+
+	return finalState, nil
+}
+
+// violatedGuard returns why the run must be left unconfirmed, if the plan goes
+// over any of the limits. A plan without a known delta can't be checked, so it
+// violates any limit that is set.
+func (cfg *unconfirmedConfiguration) violatedGuard(run *structs.Run) string {
+	guards := []struct {
+		verb  string
+		limit int
+		field string
+	}{
+		{"adds", cfg.maxResourcesAdded, "max_resources_added"},
+		{"changes", cfg.maxResourcesChanged, "max_resources_changed"},
+		{"deletes", cfg.maxResourcesDeleted, "max_resources_deleted"},
+	}
+
+	if run.Delta == nil {
+		for _, guard := range guards {
+			if guard.limit >= 0 {
+				return fmt.Sprintf("the number of resources the plan %s is unknown, so %s (%d) can't be checked", guard.verb, guard.field, guard.limit)
+			}
+		}
+
+		return ""
+	}
+
+	for i, actual := range []int{run.Delta.AddCount, run.Delta.ChangeCount, run.Delta.DeleteCount} {
+		if guard := guards[i]; guard.limit >= 0 && actual > guard.limit {
+			return fmt.Sprintf("plan %s %d resources, more than %s (%d)", guard.verb, actual, guard.field, guard.limit)
+		}
+	}
+
+	return ""
+}
+
 func resourceRunCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var mutation struct {
 		ID string `graphql:"runResourceCreate(stack: $stack, commitSha: $sha, proposed: $proposed)"`
@@ -225,36 +607,133 @@ func resourceRunCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diag.Errorf("could not trigger run for stack %s: %v", stackID, internal.FromSpaceliftError(err))
 	}
 
+	var diags diag.Diagnostics
+
 	if waitRaw, ok := d.GetOk("wait"); ok {
 		wait := expandWaitConfiguration(waitRaw.([]interface{}))
-		if diag := wait.Wait(ctx, d, client, stackID, mutation.ID); len(diag) > 0 {
-			return diag
+		diags = wait.Wait(ctx, d.Timeout(schema.TimeoutCreate), client, stackID, mutation.ID)
+
+		if logs := expandLogsConfiguration(d.Get("logs").([]interface{})); logs != nil && !wait.disabled {
+			diags = logs.Capture(ctx, client, stackID, mutation.ID, diags)
+		}
+
+		if diags.HasError() {
+			return diags
 		}
 	}
 
 	d.SetId(mutation.ID)
+
+	return append(diags, resourceRunRead(ctx, d, meta)...)
+}
+
+func resourceRunRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	stackID := d.Get("stack_id").(string)
+
+	run, err := getRun(ctx, meta.(*internal.Client), stackID, d.Id())
+	if err != nil {
+		return diag.Errorf("could not query for run %s of stack %s: %v", d.Id(), stackID, internal.FromSpaceliftError(err))
+	}
+
+	if run == nil {
+		d.SetId("")
+		return nil
+	}
+
+	run.PopulateResourceData(d)
+
 	return nil
 }
 
-func checkStackStatusFunc(ctx context.Context, client *internal.Client, stackID string, runID string) retry.StateRefreshFunc {
-	return func() (result any, state string, err error) {
-		// instead of a resource handle we return the current state as result
-		// Makes it easier to detect which end state has been reached.
-		// Otherwise we would need another GraphQL query
-		result, finished, err := getStackRunStateByID(ctx, client, stackID, runID)
-		if err != nil {
-			return
-		}
-		state = "running"
-		if finished {
-			state = "finished"
-		}
-		// Let's treat unconfirmed as the target state.
-		// It's not finished, but we don't want to wait for it because it requires confirmation from someone.
-		if result == "unconfirmed" {
-			state = "unconfirmed"
-		}
-		return
+func resourceRunImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.SplitN(d.Id(), "/", 2)
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, errors.Errorf("unexpected resource ID %q, expected $STACK_ID/$RUN_ID", d.Id())
+	}
+
+	stackID, runID := idParts[0], idParts[1]
+
+	d.SetId(runID)
+	d.Set("stack_id", stackID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getRun returns the run with the given ID on the given stack, or nil if
+// either of them does not exist.
+func getRun(ctx context.Context, client *internal.Client, stackID, runID string) (*structs.Run, error) {
+	var query struct {
+		Stack *struct {
+			Run *structs.Run `graphql:"run(id: $runId)"`
+		} `graphql:"stack(id: $stackId)"`
+	}
+
+	variables := map[string]interface{}{
+		"stackId": toID(stackID),
+		"runId":   toID(runID),
+	}
+
+	if err := client.Query(ctx, "RunRead", &query, variables); err != nil {
+		return nil, err
+	}
+
+	if query.Stack == nil {
+		return nil, nil
+	}
+
+	return query.Stack.Run, nil
+}
+
+func checkStackStatusFunc(client *internal.Client, stackID string, runID string) waiter.RefreshFunc {
+	return func(ctx context.Context) (string, bool, error) {
+		return getStackRunStateByID(ctx, client, stackID, runID)
+	}
+}
+
+// runStateSubscription streams the state changes of a run, so that waiting for
+// it doesn't need to poll.
+const runStateSubscription = `subscription RunStateChanged($stackId: ID!, $runId: ID!) {
+  runStateChanged(stack: $stackId, run: $runId) {
+    state
+    finished
+  }
+}`
+
+func watchStackRunStateFunc(client *internal.Client, stackID string, runID string) waiter.WatchFunc {
+	variables := map[string]interface{}{
+		"stackId": stackID,
+		"runId":   runID,
+	}
+
+	return func(ctx context.Context, updates chan<- waiter.Update) error {
+		return client.Subscribe(ctx, "RunStateChanged", runStateSubscription, variables, func(data json.RawMessage) error {
+			var event struct {
+				RunStateChanged *struct {
+					State    string `json:"state"`
+					Finished bool   `json:"finished"`
+				} `json:"runStateChanged"`
+			}
+
+			if err := json.Unmarshal(data, &event); err != nil {
+				return errors.Wrap(err, "could not decode run state change")
+			}
+
+			if event.RunStateChanged == nil {
+				return nil
+			}
+
+			update := waiter.Update{
+				State:    strings.ToLower(event.RunStateChanged.State),
+				Terminal: event.RunStateChanged.Finished,
+			}
+
+			select {
+			case updates <- update:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
 	}
 }
 
@@ -2,12 +2,17 @@ package spacelift
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
 	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
 )
 
@@ -43,12 +48,35 @@ func TestRunResource(t *testing.T) {
 					resourceName,
 					Attribute("id", IsNotEmpty()),
 					Attribute("stack_id", Contains(randomID)),
+					Attribute("state", IsNotEmpty()),
+					Attribute("created_at", IsNotEmpty()),
+					Attribute("proposed", Equals("false")),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: runImportID(resourceName),
+				ImportStateVerify: true,
+				// Keepers only exist in the config, and the run state may have
+				// moved on since the last refresh.
+				ImportStateVerifyIgnore: []string{"keepers", "state", "finished", "finished_at", "resources_added", "resources_changed", "resources_deleted"},
+			},
 		})
 	})
 }
 
+func runImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["stack_id"], rs.Primary.ID), nil
+	}
+}
+
 func TestRunResourceWait(t *testing.T) {
 
 	t.Run("on a new stack", func(t *testing.T) {
@@ -133,6 +161,43 @@ func TestRunResourceWait(t *testing.T) {
 		})
 	})
 
+	t.Run("timed out run is stopped", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		randomIDwp := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_worker_pool" "test" {
+						name        = "Let's create a dummy worker pool to avoid running the job %s"
+					}
+
+					resource "spacelift_stack" "test" {
+						name           = "Test stack %s"
+						repository     = "demo"
+						branch         = "feat_wait_for_run"
+						worker_pool_id = spacelift_worker_pool.test.id
+					}
+
+					resource "spacelift_run" "test" {
+						stack_id = spacelift_stack.test.id
+
+						keepers = { "bacon" = "tasty" }
+
+						timeouts {
+							create = "10s"
+						}
+
+						wait {
+							disabled          = false
+							cancel_on_timeout = true
+						}
+					}`, randomIDwp, randomID),
+				ExpectError: regexp.MustCompile("run [0-9A-Z]* on stack test-stack-[a-z0-9]* has timed out and has been stopped, final state: [a-z_]+"),
+			},
+		})
+	})
+
 	t.Run("continue on unconfirmed", func(t *testing.T) {
 		const resourceName = "spacelift_run.test"
 
@@ -170,6 +235,88 @@ func TestRunResourceWait(t *testing.T) {
 		})
 	})
 
+	t.Run("confirm unconfirmed", func(t *testing.T) {
+		const resourceName = "spacelift_run.test"
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_stack" "test" {
+						name           = "Test stack %s"
+						repository     = "demo"
+						branch         = "feat_wait_for_run"
+					}
+
+					resource "spacelift_run" "test" {
+						stack_id = spacelift_stack.test.id
+
+						keepers = { "bacon" = "tasty" }
+
+						timeouts {
+							create = "180s"
+						}
+
+						wait {
+							disabled = false
+
+							on_unconfirmed {
+								action                = "confirm"
+								max_resources_deleted = 0
+							}
+						}
+					}`, randomID),
+				Check: Resource(
+					resourceName,
+					Attribute("id", IsNotEmpty()),
+					Attribute("state", Equals("finished")),
+				),
+			},
+		})
+	})
+
+	t.Run("discard unconfirmed", func(t *testing.T) {
+		const resourceName = "spacelift_run.test"
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_stack" "test" {
+						name           = "Test stack %s"
+						repository     = "demo"
+						branch         = "feat_wait_for_run"
+					}
+
+					resource "spacelift_run" "test" {
+						stack_id = spacelift_stack.test.id
+
+						keepers = { "bacon" = "tasty" }
+
+						timeouts {
+							create = "180s"
+						}
+
+						wait {
+							disabled          = false
+							continue_on_state = ["discarded"]
+
+							on_unconfirmed {
+								action = "discard"
+							}
+						}
+					}`, randomID),
+				Check: Resource(
+					resourceName,
+					Attribute("id", IsNotEmpty()),
+					Attribute("state", Equals("discarded")),
+				),
+			},
+		})
+	})
+
 	t.Run("finished with autodeploy", func(t *testing.T) {
 		const resourceName = "spacelift_run.test"
 
@@ -206,4 +353,190 @@ func TestRunResourceWait(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("capture logs", func(t *testing.T) {
+		const resourceName = "spacelift_run.test"
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		logPath := filepath.Join(t.TempDir(), "run.log")
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_stack" "test" {
+						name           = "Test stack %s"
+						repository     = "demo"
+						branch         = "feat_wait_for_run"
+						autodeploy     = true
+					}
+
+					resource "spacelift_run" "test" {
+						stack_id = spacelift_stack.test.id
+
+						keepers = { "bacon" = "tasty" }
+
+						timeouts {
+							create = "180s"
+						}
+
+						wait {
+							disabled = false
+						}
+
+						logs {
+							phases      = "all"
+							output_path = %q
+						}
+					}`, randomID, logPath),
+				Check: resource.ComposeTestCheckFunc(
+					Resource(
+						resourceName,
+						Attribute("id", IsNotEmpty()),
+						Attribute("state", Equals("finished")),
+					),
+					func(*terraform.State) error {
+						content, err := os.ReadFile(logPath)
+						if err != nil {
+							return err
+						}
+						if !strings.Contains(string(content), "=== PLANNING ===") {
+							return fmt.Errorf("planning logs not found in %s", logPath)
+						}
+						return nil
+					},
+				),
+			},
+		})
+	})
+
+	t.Run("logs require wait", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		config := func(wait string) string {
+			return fmt.Sprintf(`
+				resource "spacelift_stack" "test" {
+					name       = "Test stack %s"
+					repository = "demo"
+					branch     = "master"
+				}
+
+				resource "spacelift_run" "test" {
+					stack_id = spacelift_stack.test.id
+
+					%s
+
+					logs {
+						phases = "all"
+					}
+				}`, randomID, wait)
+		}
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile("logs can only be captured if wait is set"),
+			},
+			{
+				Config:      config("wait { disabled = true }"),
+				ExpectError: regexp.MustCompile("logs can't be captured if wait is disabled"),
+			},
+		})
+	})
+}
+
+func TestUnconfirmedConfigurationViolatedGuard(t *testing.T) {
+	newRun := func(added, changed, deleted int) *structs.Run {
+		run := &structs.Run{}
+		run.Delta = &struct {
+			AddCount    int `graphql:"addCount"`
+			ChangeCount int `graphql:"changeCount"`
+			DeleteCount int `graphql:"deleteCount"`
+		}{added, changed, deleted}
+		return run
+	}
+
+	limits := func(added, changed, deleted int) *unconfirmedConfiguration {
+		return &unconfirmedConfiguration{
+			action:              unconfirmedActionConfirm,
+			maxResourcesAdded:   added,
+			maxResourcesChanged: changed,
+			maxResourcesDeleted: deleted,
+		}
+	}
+
+	tests := []struct {
+		name string
+		cfg  *unconfirmedConfiguration
+		run  *structs.Run
+		want string
+	}{
+		{
+			name: "unset limits allow any plan",
+			cfg:  limits(-1, -1, -1),
+			run:  newRun(100, 100, 100),
+			want: "",
+		},
+		{
+			name: "plan without a delta violates any set limit",
+			cfg:  limits(-1, -1, 0),
+			run:  &structs.Run{},
+			want: "the number of resources the plan deletes is unknown, so max_resources_deleted (0) can't be checked",
+		},
+		{
+			name: "plan without a delta passes if no limit is set",
+			cfg:  limits(-1, -1, -1),
+			run:  &structs.Run{},
+			want: "",
+		},
+		{
+			name: "added equal to the limit",
+			cfg:  limits(2, -1, -1),
+			run:  newRun(2, 5, 5),
+			want: "",
+		},
+		{
+			name: "added over the limit",
+			cfg:  limits(2, -1, -1),
+			run:  newRun(3, 0, 0),
+			want: "plan adds 3 resources, more than max_resources_added (2)",
+		},
+		{
+			name: "changed equal to the limit",
+			cfg:  limits(-1, 2, -1),
+			run:  newRun(5, 2, 5),
+			want: "",
+		},
+		{
+			name: "changed over the limit",
+			cfg:  limits(-1, 2, -1),
+			run:  newRun(0, 3, 0),
+			want: "plan changes 3 resources, more than max_resources_changed (2)",
+		},
+		{
+			name: "deleted equal to the limit",
+			cfg:  limits(-1, -1, 0),
+			run:  newRun(5, 5, 0),
+			want: "",
+		},
+		{
+			name: "deleted over the limit",
+			cfg:  limits(-1, -1, 0),
+			run:  newRun(0, 0, 1),
+			want: "plan deletes 1 resources, more than max_resources_deleted (0)",
+		},
+		{
+			name: "first violated limit is reported",
+			cfg:  limits(0, 0, 0),
+			run:  newRun(1, 1, 1),
+			want: "plan adds 1 resources, more than max_resources_added (0)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.violatedGuard(tt.run); got != tt.want {
+				t.Errorf("violatedGuard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
 }
@@ -3,10 +3,12 @@ package spacelift
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/tfstate"
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
 )
 
@@ -23,18 +26,26 @@ func resourceStack() *schema.Resource {
 			"`spacelift_stack` combines source code and configuration to create a " +
 			"runtime environment where resources are managed. In this way it's " +
 			"similar to a stack in AWS CloudFormation, or a project on generic " +
-			"CI/CD platforms.",
+			"CI/CD platforms. " +
+			"The `slug` of a new stack is computed from its name at plan time, " +
+			"but its `id` stays unknown until it's created, so other resources " +
+			"should reference `slug` to be planned in the same run.",
 
 		CreateContext: resourceStackCreate,
 		ReadContext:   resourceStackRead,
 		UpdateContext: resourceStackUpdate,
 		DeleteContext: resourceStackDelete,
 
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			return planSlug(d, "slug")
+		},
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceStackImport,
 		},
 
 		Schema: map[string]*schema.Schema{
+			"adopt_existing": adoptExistingSchema("stack"),
 			"administrative": {
 				Type:        schema.TypeBool,
 				Description: "Indicates whether this stack can manage others. Defaults to `false`.",
@@ -316,6 +327,7 @@ func resourceStack() *schema.Resource {
 				Description: "Free-form stack description for users",
 				Optional:    true,
 			},
+			"destroy_on_delete": destroyOnDeleteSchema(),
 			"enable_local_preview": {
 				Type:        schema.TypeBool,
 				Description: "Indicates whether local preview runs can be triggered on this Stack. Defaults to `false`.",
@@ -402,11 +414,17 @@ func resourceStack() *schema.Resource {
 			},
 			"import_state_file": {
 				Type:             schema.TypeString,
-				Description:      "Path to the state file to upload when creating a new stack",
+				Description:      "Path to the state file to upload when creating a new stack. The file may be gzip- or zstd-compressed.",
 				ConflictsWith:    []string{"import_state"},
 				Optional:         true,
 				DiffSuppressFunc: ignoreOnceCreated,
 			},
+			"import_state_lineage": {
+				Type:             schema.TypeString,
+				Description:      "Expected lineage of the imported state. If set, the import fails when the lineage of the state does not match it.",
+				Optional:         true,
+				DiffSuppressFunc: ignoreOnceCreated,
+			},
 			"kubernetes": {
 				Type:          schema.TypeList,
 				ConflictsWith: []string{"ansible", "cloudformation", "pulumi", "terraform_version", "terraform_workflow_tool", "terraform_workspace", "terragrunt"},
@@ -491,7 +509,7 @@ func resourceStack() *schema.Resource {
 			},
 			"slug": {
 				Type:        schema.TypeString,
-				Description: "Allows setting the custom ID (slug) for the stack",
+				Description: "Allows setting the custom ID (slug) for the stack. If not set, it's computed from `name` at plan time, so unlike `id` it can be referenced before the stack is created. Resources depending on the stack should reference `slug` rather than `id`, which is unknown until the stack is created.",
 				Optional:    true,
 				ForceNew:    true,
 				Computed:    true,
@@ -628,6 +646,10 @@ func resourceStack() *schema.Resource {
 				Optional:    true,
 			},
 		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(2 * time.Hour),
+		},
 	}
 }
 
@@ -645,32 +667,38 @@ func resourceStackCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		"slug":          (*graphql.String)(nil),
 	}
 
-	if slug, ok := d.GetOk("slug"); ok {
-		variables["slug"] = toOptionalString(slug)
+	// The slug may have been computed from the name at plan time, in which
+	// case it's the server's call to assign it.
+	if slug := d.GetRawConfig().GetAttr("slug"); !slug.IsNull() && slug.IsKnown() {
+		variables["slug"] = toOptionalString(slug.AsString())
 	}
 
-	var stateContent string
+	var openState func() (io.ReadCloser, error)
 
 	content, ok := d.GetOk("import_state")
 	if ok && !manageState {
 		return diag.Errorf(`"import_state" requires "manage_state" to be true`)
 	} else if ok {
-		stateContent = content.(string)
+		openState = func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(content.(string))), nil
+		}
 	}
 
 	path, ok := d.GetOk("import_state_file")
 	if ok && !manageState {
 		return diag.Errorf(`"import_state_file" requires "manage_state" to be true`)
 	} else if ok {
-		data, err := os.ReadFile(path.(string))
-		if err != nil {
-			return diag.Errorf("failed to read imported state file: %s", err)
+		openState = func() (io.ReadCloser, error) {
+			file, err := os.Open(path.(string))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read imported state file")
+			}
+			return file, nil
 		}
-		stateContent = string(data)
 	}
 
-	if stateContent != "" {
-		objectID, err := uploadStateFile(ctx, stateContent, meta)
+	if openState != nil {
+		objectID, err := uploadStateFile(ctx, openState, d.Get("import_state_lineage").(string), meta)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -684,12 +712,25 @@ func resourceStackCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	if err := meta.(*internal.Client).Mutate(ctx, "StackCreate", &mutation, variables); err != nil {
-		return diag.Errorf("could not create stack: %v", internal.FromSpaceliftError(err))
+		diags := diag.Errorf("could not create stack: %v", internal.FromSpaceliftError(err))
+
+		// Imported state can only be used by a new stack.
+		if d.Get("adopt_existing").(bool) && openState == nil {
+			return adoptExisting(ctx, d, meta, "stack", d.Get("slug").(string), err, diags, lookupExistingStack, resourceStackUpdate)
+		}
+
+		return diags
 	}
 
+	planned := d.Get("slug").(string)
+
 	d.SetId(mutation.CreateStack.ID)
 
-	return resourceStackRead(ctx, d, meta)
+	if diags := resourceStackRead(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return checkSlug("stack", planned, mutation.CreateStack.ID)
 }
 
 func getStackByID(ctx context.Context, client *internal.Client, stackID string) (*structs.Stack, error) {
@@ -706,6 +747,15 @@ func getStackByID(ctx context.Context, client *internal.Client, stackID string)
 	return query.Stack, nil
 }
 
+func lookupExistingStack(ctx context.Context, client *internal.Client, id string) (string, bool, error) {
+	stack, err := getStackByID(ctx, client, id)
+	if err != nil || stack == nil {
+		return "", false, err
+	}
+
+	return stack.Space, true, nil
+}
+
 func resourceStackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	stack, err := getStackByID(ctx, meta.(*internal.Client), d.Id())
 	if err != nil {
@@ -744,6 +794,17 @@ func resourceStackUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourceStackDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if destroy := expandDestroyOnDeleteConfiguration(d.Get("destroy_on_delete").([]interface{})); destroy != nil {
+		// Let's not destroy the resources of a stack we won't be able to delete.
+		if d.Get("protect_from_deletion").(bool) {
+			return diag.Errorf("stack %s is protected from deletion, refusing to destroy its resources", d.Id())
+		}
+
+		if diags := destroy.Destroy(ctx, meta.(*internal.Client), d.Id(), d.Timeout(schema.TimeoutDelete)); diags.HasError() {
+			return diags
+		}
+	}
+
 	var mutation struct {
 		DeleteStack *structs.Stack `graphql:"stackDelete(id: $id)"`
 	}
@@ -1010,7 +1071,37 @@ func getStrings(d *schema.ResourceData, fieldName string) []graphql.String {
 	return values
 }
 
-func uploadStateFile(ctx context.Context, content string, meta interface{}) (string, error) {
+// uploadStateFile validates the state returned by open and streams it to
+// Spacelift, returning the ID of the uploaded object.
+func uploadStateFile(ctx context.Context, open func() (io.ReadCloser, error), expectedLineage string, meta interface{}) (string, error) {
+	openDecompressed := func() (io.ReadCloser, error) {
+		raw, err := open()
+		if err != nil {
+			return nil, err
+		}
+		return tfstate.Decompress(raw)
+	}
+
+	state, err := openDecompressed()
+	if err != nil {
+		return "", err
+	}
+	metadata, size, err := tfstate.Inspect(state)
+	state.Close()
+	if err != nil {
+		return "", errors.Wrap(err, "invalid imported state")
+	}
+
+	if expectedLineage != "" && metadata.Lineage != expectedLineage {
+		return "", errors.Errorf("imported state has lineage %q, expected %q", metadata.Lineage, expectedLineage)
+	}
+
+	tflog.Debug(ctx, "uploading imported state", map[string]interface{}{
+		"lineage": metadata.Lineage,
+		"serial":  metadata.Serial,
+		"size":    size,
+	})
+
 	var mutation struct {
 		StateUploadURL struct {
 			ObjectID string `graphql:"objectId"`
@@ -1018,23 +1109,16 @@ func uploadStateFile(ctx context.Context, content string, meta interface{}) (str
 		} `graphql:"stateUploadUrl"`
 	}
 
-	if err := meta.(*internal.Client).Mutate(ctx, "StateUploadUrl", &mutation, nil); err != nil {
-		return "", errors.Wrap(err, "could not generate state upload URL")
-	}
+	client := meta.(*internal.Client)
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodPut, mutation.StateUploadURL.URL, strings.NewReader(content))
-	if err != nil {
-		return "", errors.Wrap(err, "could not create state upload request")
+	if err := client.Mutate(ctx, "StateUploadUrl", &mutation, nil); err != nil {
+		return "", errors.Wrap(err, "could not generate state upload URL")
 	}
-	request.Header.Set("Content-Type", "application/json")
 
-	response, err := http.DefaultClient.Do(request)
-	if err != nil {
-		return "", errors.Wrap(err, "could not upload the state to remote URL")
-	}
+	body := func() (io.Reader, error) { return openDecompressed() }
 
-	if (response.StatusCode / 100) != 2 {
-		return "", errors.Errorf("unexpected HTTP status code when uploading the state: %d", response.StatusCode)
+	if err := client.Upload(ctx, mutation.StateUploadURL.URL, body, size, "application/json"); err != nil {
+		return "", errors.Wrap(err, "could not upload the state")
 	}
 
 	return mutation.StateUploadURL.ObjectID, nil
@@ -0,0 +1,396 @@
+package spacelift
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+func resourceStackDependencies() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_stack_dependencies` manages all the **stack dependencies** of a single " +
+			"stack, along with their references, in one resource. Dependencies and references of " +
+			"the stack which are not declared here are removed, so it should not be combined with " +
+			"`spacelift_stack_dependency` or `spacelift_stack_dependency_reference` for the same stack.",
+
+		CreateContext: resourceStackDependenciesCreate,
+		ReadContext:   resourceStackDependenciesRead,
+		UpdateContext: resourceStackDependenciesUpdate,
+		DeleteContext: resourceStackDependenciesDelete,
+
+		CustomizeDiff: resourceStackDependenciesCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"stack_id": {
+				Type:             schema.TypeString,
+				Description:      "immutable ID (slug) of the stack which has the dependencies.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"dependency": {
+				Type:        schema.TypeSet,
+				Description: "Stacks the stack depends on, with the references to their outputs.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"depends_on_stack_id": {
+							Type:             schema.TypeString,
+							Description:      "immutable ID (slug) of the stack to depend on.",
+							Required:         true,
+							ValidateDiagFunc: validations.DisallowEmptyString,
+						},
+						"reference": {
+							Type:        schema.TypeSet,
+							Description: "References matching the outputs of the stack to depend on to the inputs of the stack.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"output_name": {
+										Type:             schema.TypeString,
+										Description:      "Name of the output of the stack to depend on",
+										Required:         true,
+										ValidateDiagFunc: validations.DisallowEmptyString,
+									},
+									"input_name": {
+										Type:        schema.TypeString,
+										Description: "Name of the input: the name of the environment variable, or the path of the mounted file relative to /mnt/workspace/",
+										Required:    true,
+									},
+									"type": {
+										Type:         schema.TypeString,
+										Description:  "How the output is passed to the stack: `ENVIRONMENT_VARIABLE` or `FILE_MOUNT`. Defaults to `ENVIRONMENT_VARIABLE`.",
+										Optional:     true,
+										Default:      stackDependencyReferenceTypeEnvironmentVariable,
+										ValidateFunc: validation.StringInSlice([]string{stackDependencyReferenceTypeEnvironmentVariable, stackDependencyReferenceTypeFileMount}, false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// stackDependencyWithReferences is an existing dependency of a stack.
+type stackDependencyWithReferences struct {
+	ID             string                             `graphql:"id"`
+	DependsOnStack structs.StackDependencyDetail      `graphql:"dependsOnStack"`
+	References     []structs.StackDependencyReference `graphql:"references"`
+}
+
+// stackDependencyReferenceKey identifies a reference within a dependency. The
+// same output may be passed as several inputs.
+type stackDependencyReferenceKey struct {
+	outputName string
+	inputName  string
+}
+
+func resourceStackDependenciesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	stackID := d.Get("stack_id").(string)
+
+	if diags := applyStackDependencies(ctx, meta.(*internal.Client), stackID, expandStackDependencies(d.Get("dependency").(*schema.Set))); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(stackID)
+
+	return resourceStackDependenciesRead(ctx, d, meta)
+}
+
+func resourceStackDependenciesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dependencies, err := getStackDependencies(ctx, meta.(*internal.Client), d.Id())
+	if err != nil {
+		return diag.Errorf("could not query for stack dependencies: %v", internal.FromSpaceliftError(err))
+	}
+
+	if dependencies == nil {
+		d.SetId("")
+		return nil
+	}
+
+	var dependencyList []interface{}
+	for _, dependency := range dependencies {
+		var references []interface{}
+		for _, reference := range dependency.References {
+			references = append(references, map[string]interface{}{
+				"output_name": reference.OutputName,
+				"input_name":  reference.InputName,
+				"type":        reference.Type,
+			})
+		}
+
+		dependencyList = append(dependencyList, map[string]interface{}{
+			"depends_on_stack_id": dependency.DependsOnStack.ID,
+			"reference":           references,
+		})
+	}
+
+	d.Set("stack_id", d.Id())
+
+	if err := d.Set("dependency", dependencyList); err != nil {
+		return diag.Errorf("could not set dependency: %v", err)
+	}
+
+	return nil
+}
+
+func resourceStackDependenciesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := applyStackDependencies(ctx, meta.(*internal.Client), d.Id(), expandStackDependencies(d.Get("dependency").(*schema.Set))); diags.HasError() {
+		return diags
+	}
+
+	return resourceStackDependenciesRead(ctx, d, meta)
+}
+
+// resourceStackDependenciesCustomizeDiff rejects invalid dependencies, and the
+// ones which would create a cycle in the graph of existing dependencies.
+func resourceStackDependenciesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("dependency") || !d.NewValueKnown("dependency") {
+		return nil
+	}
+
+	dependencies := d.Get("dependency").(*schema.Set)
+	if err := validateStackDependencies(dependencies); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("stack_id") {
+		return nil
+	}
+
+	stackID := d.Get("stack_id").(string)
+	graph := newStackDependencyGraph(meta.(*internal.Client))
+
+	for dependsOnStackID := range expandStackDependencies(dependencies) {
+		path, err := graph.findPath(ctx, dependsOnStackID, stackID)
+		if err != nil {
+			return errors.Wrap(internal.FromSpaceliftError(err), "could not check stack dependencies for cycles")
+		}
+
+		if path != nil {
+			cycle := append([]string{stackID}, path...)
+			return errors.Errorf("stack %s depending on %s would create a dependency cycle: %s", stackID, dependsOnStackID, formatDependencyPath(cycle))
+		}
+	}
+
+	return nil
+}
+
+func resourceStackDependenciesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := applyStackDependencies(ctx, meta.(*internal.Client), d.Id(), nil); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// validateStackDependencies rejects dependencies on the same stack declared
+// more than once, which would otherwise be silently merged, and checks the
+// input names of the references the same way spacelift_stack_dependency_reference
+// does.
+func validateStackDependencies(set *schema.Set) error {
+	seen := make(map[string]bool)
+
+	for _, item := range set.List() {
+		dependency := item.(map[string]interface{})
+
+		dependsOnStackID := dependency["depends_on_stack_id"].(string)
+		if seen[dependsOnStackID] {
+			return errors.Errorf("dependency on stack %s is declared more than once", dependsOnStackID)
+		}
+		seen[dependsOnStackID] = true
+
+		references := make(map[stackDependencyReferenceKey]bool)
+
+		for _, referenceItem := range dependency["reference"].(*schema.Set).List() {
+			reference := referenceItem.(map[string]interface{})
+			key := stackDependencyReferenceKey{outputName: reference["output_name"].(string), inputName: reference["input_name"].(string)}
+
+			if references[key] {
+				return errors.Errorf("reference of output %s of stack %s to input %s is declared more than once", key.outputName, dependsOnStackID, key.inputName)
+			}
+			references[key] = true
+
+			if err := validateStackDependencyReferenceInput(reference["type"].(string), key.inputName); err != nil {
+				return errors.Wrapf(err, "invalid reference to output %s of stack %s", key.outputName, dependsOnStackID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// expandStackDependencies returns the types of the declared references, keyed
+// by the ID of the stack depended on and then by output and input name.
+func expandStackDependencies(set *schema.Set) map[string]map[stackDependencyReferenceKey]string {
+	dependencies := make(map[string]map[stackDependencyReferenceKey]string)
+
+	for _, item := range set.List() {
+		dependency := item.(map[string]interface{})
+
+		references := make(map[stackDependencyReferenceKey]string)
+		for _, referenceItem := range dependency["reference"].(*schema.Set).List() {
+			reference := referenceItem.(map[string]interface{})
+			key := stackDependencyReferenceKey{outputName: reference["output_name"].(string), inputName: reference["input_name"].(string)}
+			references[key] = reference["type"].(string)
+		}
+
+		dependencies[dependency["depends_on_stack_id"].(string)] = references
+	}
+
+	return dependencies
+}
+
+// applyStackDependencies brings the dependencies of the stack in line with the
+// declared ones, touching only the dependencies and references which differ.
+func applyStackDependencies(ctx context.Context, client *internal.Client, stackID string, declared map[string]map[stackDependencyReferenceKey]string) diag.Diagnostics {
+	existing, err := getStackDependencies(ctx, client, stackID)
+	if err != nil {
+		return diag.Errorf("could not query for stack dependencies: %v", internal.FromSpaceliftError(err))
+	}
+
+	existingByStack := make(map[string]stackDependencyWithReferences)
+	for _, dependency := range existing {
+		existingByStack[dependency.DependsOnStack.ID] = dependency
+	}
+
+	for _, dependency := range existing {
+		if _, ok := declared[dependency.DependsOnStack.ID]; ok {
+			continue
+		}
+
+		if err := deleteStackDependency(ctx, client, toID(dependency.ID)); err != nil {
+			return diag.Errorf("could not delete dependency of stack %s on %s: %v", stackID, dependency.DependsOnStack.ID, internal.FromSpaceliftError(err))
+		}
+	}
+
+	dependsOnStackIDs := make([]string, 0, len(declared))
+	for dependsOnStackID := range declared {
+		dependsOnStackIDs = append(dependsOnStackIDs, dependsOnStackID)
+	}
+	slices.Sort(dependsOnStackIDs)
+
+	for _, dependsOnStackID := range dependsOnStackIDs {
+		dependency, ok := existingByStack[dependsOnStackID]
+		if !ok {
+			created, err := createStackDependency(ctx, client, structs.StackDependencyInput{
+				StackID:          toID(stackID),
+				DependsOnStackID: toID(dependsOnStackID),
+			})
+			if err != nil {
+				return diag.Errorf("could not create dependency of stack %s on %s: %v", stackID, dependsOnStackID, internal.FromSpaceliftError(err))
+			}
+			dependency = stackDependencyWithReferences{ID: created.ID}
+		}
+
+		if err := applyStackDependencyReferences(ctx, client, dependency, declared[dependsOnStackID]); err != nil {
+			return diag.Errorf("could not update references of stack %s to %s: %v", stackID, dependsOnStackID, internal.FromSpaceliftError(err))
+		}
+	}
+
+	return nil
+}
+
+func applyStackDependencyReferences(ctx context.Context, client *internal.Client, dependency stackDependencyWithReferences, declared map[stackDependencyReferenceKey]string) error {
+	existing := make(map[stackDependencyReferenceKey]bool)
+
+	for _, reference := range dependency.References {
+		key := stackDependencyReferenceKey{outputName: reference.OutputName, inputName: reference.InputName}
+
+		referenceType, ok := declared[key]
+		if !ok || existing[key] {
+			if err := deleteStackDependencyReference(ctx, client, toID(reference.ID)); err != nil {
+				return errors.Wrapf(err, "could not delete reference of output %s to %s", reference.OutputName, reference.InputName)
+			}
+			continue
+		}
+
+		if reference.Type != referenceType {
+			_, err := updateStackDependencyReference(ctx, client, structs.StackDependencyReferenceUpdateInput{
+				ID:            toID(reference.ID),
+				OutputName:    toString(reference.OutputName),
+				InputName:     toString(reference.InputName),
+				Type:          toString(referenceType),
+				TriggerAlways: reference.TriggerAlways,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "could not update reference of output %s to %s", reference.OutputName, reference.InputName)
+			}
+		}
+
+		existing[key] = true
+	}
+
+	keys := make([]stackDependencyReferenceKey, 0, len(declared))
+	for key := range declared {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b stackDependencyReferenceKey) int {
+		if c := cmp.Compare(a.outputName, b.outputName); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.inputName, b.inputName)
+	})
+
+	for _, key := range keys {
+		if existing[key] {
+			continue
+		}
+
+		_, err := addStackDependencyReference(ctx, client, toID(dependency.ID), structs.StackDependencyReferenceInput{
+			OutputName: toString(key.outputName),
+			InputName:  toString(key.inputName),
+			Type:       toString(declared[key]),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not add reference of output %s to %s", key.outputName, key.inputName)
+		}
+	}
+
+	return nil
+}
+
+// getStackDependencies returns the dependencies of the stack, or nil if the
+// stack does not exist.
+func getStackDependencies(ctx context.Context, client *internal.Client, stackID string) ([]stackDependencyWithReferences, error) {
+	var query struct {
+		Stack *struct {
+			DependsOn []stackDependencyWithReferences `graphql:"dependsOn"`
+		} `graphql:"stack(id: $id)"`
+	}
+
+	variables := map[string]interface{}{"id": toID(stackID)}
+
+	if err := client.Query(ctx, "StackDependenciesRead", &query, variables); err != nil {
+		return nil, err
+	}
+
+	if query.Stack == nil {
+		return nil, nil
+	}
+
+	if query.Stack.DependsOn == nil {
+		return []stackDependencyWithReferences{}, nil
+	}
+
+	return query.Stack.DependsOn, nil
+}
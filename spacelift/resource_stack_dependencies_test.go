@@ -0,0 +1,184 @@
+package spacelift
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestStackDependenciesResource(t *testing.T) {
+	const resourceName = "spacelift_stack_dependencies.test"
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlpha)
+
+	config := func(dependencies string) string {
+		return fmt.Sprintf(`
+			resource "spacelift_stack" "app" {
+				branch     = "master"
+				repository = "demo"
+				name       = "deps-app-%[1]s"
+			}
+
+			resource "spacelift_stack" "network" {
+				branch     = "master"
+				repository = "demo"
+				name       = "deps-network-%[1]s"
+			}
+
+			resource "spacelift_stack" "database" {
+				branch     = "master"
+				repository = "demo"
+				name       = "deps-database-%[1]s"
+			}
+
+			resource "spacelift_stack_dependencies" "test" {
+				stack_id = spacelift_stack.app.id
+				%[2]s
+			}
+		`, randomID, dependencies)
+	}
+
+	testSteps(t, []resource.TestStep{
+		{
+			Config: config(`
+				dependency {
+					depends_on_stack_id = spacelift_stack.network.id
+				}
+
+				dependency {
+					depends_on_stack_id = spacelift_stack.network.id
+
+					reference {
+						output_name = "vpc_id"
+						input_name  = "TF_VAR_vpc_id"
+					}
+				}
+			`),
+			ExpectError: regexp.MustCompile(`is declared more than once`),
+		},
+		{
+			Config: config(`
+				dependency {
+					depends_on_stack_id = spacelift_stack.network.id
+
+					reference {
+						output_name = "kubeconfig"
+						input_name  = "/etc/kubeconfig"
+						type        = "FILE_MOUNT"
+					}
+				}
+			`),
+			ExpectError: regexp.MustCompile(`must be a path relative to /mnt/workspace/`),
+		},
+		{
+			Config: config(`
+				dependency {
+					depends_on_stack_id = spacelift_stack.network.id
+					reference {
+						output_name = "vpc_id"
+						input_name  = "TF_VAR_vpc_id"
+					}
+				}
+			`),
+			Check: Resource(
+				resourceName,
+				Attribute("id", Equals("deps-app-"+randomID)),
+				Attribute("dependency.#", Equals("1")),
+				Nested("dependency", CheckInList(
+					Attribute("depends_on_stack_id", Equals("deps-network-"+randomID)),
+					Attribute("reference.#", Equals("1")),
+					Nested("reference", CheckInList(
+						Attribute("output_name", Equals("vpc_id")),
+						Attribute("input_name", Equals("TF_VAR_vpc_id")),
+						Attribute("type", Equals("ENVIRONMENT_VARIABLE")),
+					)),
+				)),
+			),
+		},
+		{
+			Config: config(`
+				dependency {
+					depends_on_stack_id = spacelift_stack.network.id
+					reference {
+						output_name = "vpc_id"
+						input_name  = "TF_VAR_network_id"
+					}
+
+					reference {
+						output_name = "vpc_id"
+						input_name  = "TF_VAR_legacy_vpc_id"
+					}
+
+					reference {
+						output_name = "subnet_ids"
+						input_name  = "subnet_ids.json"
+						type        = "FILE_MOUNT"
+					}
+				}
+
+				dependency {
+					depends_on_stack_id = spacelift_stack.database.id
+					reference {
+						output_name = "connection_string"
+						input_name  = "APP_DB_URL"
+					}
+				}
+			`),
+			Check: Resource(
+				resourceName,
+				Attribute("dependency.#", Equals("2")),
+				Nested("dependency", CheckInList(
+					Attribute("depends_on_stack_id", Equals("deps-network-"+randomID)),
+					Attribute("reference.#", Equals("3")),
+					Nested("reference", CheckInList(
+						Attribute("output_name", Equals("vpc_id")),
+						Attribute("input_name", Equals("TF_VAR_network_id")),
+						Attribute("type", Equals("ENVIRONMENT_VARIABLE")),
+					)),
+					Nested("reference", CheckInList(
+						Attribute("output_name", Equals("vpc_id")),
+						Attribute("input_name", Equals("TF_VAR_legacy_vpc_id")),
+					)),
+					Nested("reference", CheckInList(
+						Attribute("output_name", Equals("subnet_ids")),
+						Attribute("input_name", Equals("subnet_ids.json")),
+						Attribute("type", Equals("FILE_MOUNT")),
+					)),
+				)),
+				Nested("dependency", CheckInList(
+					Attribute("depends_on_stack_id", Equals("deps-database-"+randomID)),
+					Nested("reference", CheckInList(
+						Attribute("output_name", Equals("connection_string")),
+						Attribute("input_name", Equals("APP_DB_URL")),
+					)),
+				)),
+			),
+		},
+		{
+			ResourceName:      resourceName,
+			ImportState:       true,
+			ImportStateId:     "deps-app-" + randomID,
+			ImportStateVerify: true,
+		},
+		{
+			Config: config(`
+				dependency {
+					depends_on_stack_id = spacelift_stack.database.id
+				}
+			`),
+			Check: Resource(
+				resourceName,
+				Attribute("dependency.#", Equals("1")),
+				Nested("dependency", CheckInList(
+					Attribute("depends_on_stack_id", Equals("deps-database-"+randomID)),
+					AttributeNotPresent("reference.0.output_name"),
+				)),
+			),
+		},
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
 	"github.com/shurcooL/graphql"
 
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
@@ -25,6 +26,8 @@ func resourceStackDependency() *schema.Resource {
 		ReadContext:   resourceStackDependencyRead,
 		DeleteContext: resourceStackDependencyDelete,
 
+		CustomizeDiff: resourceStackDependencyCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceStackDependencyImport,
 		},
@@ -48,29 +51,56 @@ func resourceStackDependency() *schema.Resource {
 }
 
 func resourceStackDependencyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var query struct {
-		StackDependency structs.StackDependency `graphql:"stackDependencyCreate(input: $input)"`
-	}
-
-	variables := map[string]interface{}{"input": stackDependencyCreateInput(d)}
-
-	if err := meta.(*internal.Client).Mutate(ctx, "StackDependencyCreate", &query, variables); err != nil {
+	dependency, err := createStackDependency(ctx, meta.(*internal.Client), stackDependencyCreateInput(d))
+	if err != nil {
 		return diag.Errorf("could not create stack dependency: %s", err)
 	}
 
-	d.SetId(path.Join(query.StackDependency.Stack.ID, query.StackDependency.ID))
+	d.SetId(path.Join(dependency.Stack.ID, dependency.ID))
 
 	return resourceStackDependencyRead(ctx, d, meta)
 }
 
-func resourceStackDependencyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var query struct {
-		StackDependency *structs.StackDependency `graphql:"stackDependencyDelete(id: $id)"`
+// resourceStackDependencyCustomizeDiff rejects dependencies which would create
+// a cycle in the graph of existing dependencies.
+func resourceStackDependencyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("stack_id", "depends_on_stack_id") {
+		return nil
+	}
+
+	// Stacks created in the same plan can't have any dependencies yet.
+	if !d.NewValueKnown("stack_id") || !d.NewValueKnown("depends_on_stack_id") {
+		return nil
 	}
 
-	variables := map[string]interface{}{"id": getStackDependencyId(d)}
+	stackID := d.Get("stack_id").(string)
+	dependsOnStackID := d.Get("depends_on_stack_id").(string)
 
-	if err := meta.(*internal.Client).Mutate(ctx, "StackDependencyDelete", &query, variables); err != nil {
+	graph := newStackDependencyGraph(meta.(*internal.Client))
+
+	// The dependency is about to be replaced, so its current edge must not be
+	// taken into account.
+	if d.Id() != "" {
+		oldStackID, _ := d.GetChange("stack_id")
+		oldDependsOnStackID, _ := d.GetChange("depends_on_stack_id")
+		graph.ignore(oldStackID.(string), oldDependsOnStackID.(string))
+	}
+
+	path, err := graph.findPath(ctx, dependsOnStackID, stackID)
+	if err != nil {
+		return errors.Wrap(internal.FromSpaceliftError(err), "could not check stack dependencies for cycles")
+	}
+
+	if path != nil {
+		cycle := append([]string{stackID}, path...)
+		return errors.Errorf("stack %s depending on %s would create a dependency cycle: %s", stackID, dependsOnStackID, formatDependencyPath(cycle))
+	}
+
+	return nil
+}
+
+func resourceStackDependencyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := deleteStackDependency(ctx, meta.(*internal.Client), getStackDependencyId(d)); err != nil {
 		return diag.Errorf("could not delete stack dependency: %s", err)
 	}
 
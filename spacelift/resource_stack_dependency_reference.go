@@ -4,15 +4,25 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
 
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
 )
 
+const (
+	stackDependencyReferenceTypeEnvironmentVariable = "ENVIRONMENT_VARIABLE"
+	stackDependencyReferenceTypeFileMount           = "FILE_MOUNT"
+)
+
+var environmentVariableNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 func resourceStackDependencyReference() *schema.Resource {
 	return &schema.Resource{
 		Description: "" +
@@ -25,6 +35,8 @@ func resourceStackDependencyReference() *schema.Resource {
 		UpdateContext: resourceStackDependencyReferenceUpdate,
 		DeleteContext: resourceStackDependencyReferenceDelete,
 
+		CustomizeDiff: resourceStackDependencyReferenceCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -43,9 +55,16 @@ func resourceStackDependencyReference() *schema.Resource {
 			},
 			"input_name": {
 				Type:        schema.TypeString,
-				Description: "Name of the input of the stack dependency reference",
+				Description: "Name of the input of the stack dependency reference: the name of the environment variable, or the path of the mounted file relative to /mnt/workspace/. Environment variable names are only validated if `type` is set explicitly.",
 				Required:    true,
 			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "How the output is passed to the stack: `ENVIRONMENT_VARIABLE` or `FILE_MOUNT`. Defaults to `ENVIRONMENT_VARIABLE`.",
+				Optional:     true,
+				Default:      stackDependencyReferenceTypeEnvironmentVariable,
+				ValidateFunc: validation.StringInSlice([]string{stackDependencyReferenceTypeEnvironmentVariable, stackDependencyReferenceTypeFileMount}, false),
+			},
 			"trigger_always": {
 				Type:        schema.TypeBool,
 				Description: "Whether the dependents should be triggered even if the value of the reference did not change.",
@@ -57,33 +76,26 @@ func resourceStackDependencyReference() *schema.Resource {
 }
 
 func resourceStackDependencyReferenceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var query struct {
-		StackDependencyReference structs.StackDependencyReference `graphql:"stackDependenciesAddReference(stackDependencyID: $stackDependencyID, reference: $reference)"`
-	}
-
 	stackID, depID, diags := getStackDependencyIDParts(d)
 	if diags != nil {
 		return diags
 	}
 
-	variables := map[string]interface{}{
-		"stackDependencyID": toID(depID),
-		"reference": structs.StackDependencyReferenceInput{
-			OutputName:    toString(d.Get("output_name")),
-			InputName:     toString(d.Get("input_name")),
-			Type:          toString("ENVIRONMENT_VARIABLE"),
-			TriggerAlways: toBool(d.Get("trigger_always")),
-		},
-	}
-
-	if err := meta.(*internal.Client).Mutate(ctx, "StackDependenciesAddReference", &query, variables); err != nil {
+	reference, err := addStackDependencyReference(ctx, meta.(*internal.Client), toID(depID), structs.StackDependencyReferenceInput{
+		OutputName:    toString(d.Get("output_name")),
+		InputName:     toString(d.Get("input_name")),
+		Type:          toString(d.Get("type")),
+		TriggerAlways: toBool(d.Get("trigger_always")),
+	})
+	if err != nil {
 		return diag.Errorf("could not create stack dependency reference: %s", err)
 	}
 
-	d.SetId(path.Join(stackID, depID, query.StackDependencyReference.ID))
-	d.Set("output_name", query.StackDependencyReference.OutputName)
-	d.Set("input_name", query.StackDependencyReference.InputName)
-	d.Set("trigger_always", query.StackDependencyReference.TriggerAlways)
+	d.SetId(path.Join(stackID, depID, reference.ID))
+	d.Set("output_name", reference.OutputName)
+	d.Set("input_name", reference.InputName)
+	d.Set("type", reference.Type)
+	d.Set("trigger_always", reference.TriggerAlways)
 
 	return nil
 }
@@ -134,58 +146,83 @@ func resourceStackDependencyReferenceRead(ctx context.Context, d *schema.Resourc
 	d.Set("stack_dependency_id", path.Join(stackID, depID))
 	d.Set("output_name", query.Stack.Dependency.Reference.OutputName)
 	d.Set("input_name", query.Stack.Dependency.Reference.InputName)
+	d.Set("type", query.Stack.Dependency.Reference.Type)
 	d.Set("trigger_always", query.Stack.Dependency.Reference.TriggerAlways)
 
 	return nil
 }
 
 func resourceStackDependencyReferenceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var query struct {
-		StackDependencyReference structs.StackDependencyReference `graphql:"stackDependenciesUpdateReference(reference: $reference)"`
-	}
-
 	stackID, depID, refID, diags := getStackDependencyReferenceIDParts(d)
 	if diags != nil {
 		return diags
 	}
 
-	variables := map[string]interface{}{
-		"reference": structs.StackDependencyReferenceUpdateInput{
-			ID:            toID(refID),
-			OutputName:    toString(d.Get("output_name")),
-			InputName:     toString(d.Get("input_name")),
-			Type:          toString("ENVIRONMENT_VARIABLE"),
-			TriggerAlways: toBool(d.Get("trigger_always")),
-		},
-	}
-
-	if err := meta.(*internal.Client).Mutate(ctx, "StackDependenciesUpdateReference", &query, variables); err != nil {
+	reference, err := updateStackDependencyReference(ctx, meta.(*internal.Client), structs.StackDependencyReferenceUpdateInput{
+		ID:            toID(refID),
+		OutputName:    toString(d.Get("output_name")),
+		InputName:     toString(d.Get("input_name")),
+		Type:          toString(d.Get("type")),
+		TriggerAlways: toBool(d.Get("trigger_always")),
+	})
+	if err != nil {
 		return diag.Errorf("could not update stack dependency reference: %s", err)
 	}
 
 	d.Set("stack_dependency_id", path.Join(stackID, depID))
-	d.Set("output_name", query.StackDependencyReference.OutputName)
-	d.Set("input_name", query.StackDependencyReference.InputName)
-	d.Set("trigger_always", query.StackDependencyReference.TriggerAlways)
+	d.Set("output_name", reference.OutputName)
+	d.Set("input_name", reference.InputName)
+	d.Set("type", reference.Type)
+	d.Set("trigger_always", reference.TriggerAlways)
 
 	return nil
 }
 
-func resourceStackDependencyReferenceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var query struct {
-		StackDependencyReference *structs.StackDependencyReference `graphql:"stackDependenciesDeleteReference(id: $id)"`
+// resourceStackDependencyReferenceCustomizeDiff checks that the input name is
+// valid for the type of the reference. Environment variable names used to be
+// accepted as is, so they're only checked if the type is set explicitly.
+func resourceStackDependencyReferenceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("input_name") || !d.NewValueKnown("type") {
+		return nil
 	}
 
+	referenceType := d.Get("type").(string)
+	if referenceType == stackDependencyReferenceTypeEnvironmentVariable && d.GetRawConfig().GetAttr("type").IsNull() {
+		return nil
+	}
+
+	return validateStackDependencyReferenceInput(referenceType, d.Get("input_name").(string))
+}
+
+func validateStackDependencyReferenceInput(referenceType, inputName string) error {
+	switch referenceType {
+	case stackDependencyReferenceTypeEnvironmentVariable:
+		if !environmentVariableNamePattern.MatchString(inputName) {
+			return errors.Errorf("input_name %q is not a valid environment variable name: it must only contain letters, digits and underscores, and not start with a digit", inputName)
+		}
+	case stackDependencyReferenceTypeFileMount:
+		switch {
+		case inputName == "":
+			return errors.New("input_name must be a relative path to the mounted file")
+		case path.IsAbs(inputName):
+			return errors.Errorf("input_name %q must be a path relative to /mnt/workspace/, not an absolute one", inputName)
+		case path.Clean(inputName) != inputName || inputName == ".":
+			return errors.Errorf("input_name %q must be a clean path to a file, without trailing slashes or redundant elements", inputName)
+		case inputName == ".." || strings.HasPrefix(inputName, "../"):
+			return errors.Errorf("input_name %q must not point outside of /mnt/workspace/", inputName)
+		}
+	}
+
+	return nil
+}
+
+func resourceStackDependencyReferenceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	_, _, refID, diags := getStackDependencyReferenceIDParts(d)
 	if diags != nil {
 		return diags
 	}
 
-	variables := map[string]interface{}{
-		"id": toID(refID),
-	}
-
-	if err := meta.(*internal.Client).Mutate(ctx, "StackDependenciesDeleteReference", &query, variables); err != nil {
+	if err := deleteStackDependencyReference(ctx, meta.(*internal.Client), toID(refID)); err != nil {
 		return diag.Errorf("could not delete stack dependency reference: %s", err)
 	}
 
@@ -2,6 +2,7 @@ package spacelift
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -55,6 +56,7 @@ func TestStackDependencyReferenceResource(t *testing.T) {
 					Attribute("id", IsNotEmpty()),
 					Attribute("output_name", Equals("output_abc")),
 					Attribute("input_name", Equals("input_123")),
+					Attribute("type", Equals("ENVIRONMENT_VARIABLE")),
 					Attribute("trigger_always", Equals("false")),
 				),
 			},
@@ -78,6 +80,14 @@ func TestStackDependencyReferenceResource(t *testing.T) {
 					Attribute("trigger_always", Equals("true")),
 				),
 			},
+			{ // accepts any environment variable name without an explicit type
+				Config: configWithReference("output_xyz", "input-456.legacy", true),
+				Check: Resource(
+					resourceName,
+					Attribute("input_name", Equals("input-456.legacy")),
+					Attribute("type", Equals("ENVIRONMENT_VARIABLE")),
+				),
+			},
 			{ // deletes reference
 				Config: configWithoutReference(),
 				Check: func(state *terraform.State) error {
@@ -109,4 +119,62 @@ func TestStackDependencyReferenceResource(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("mounts references as files", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		config := func(inputName, referenceType string) string {
+			return fmt.Sprintf(`
+				resource "spacelift_stack" "test1" {
+					branch     = "master"
+					repository = "demo"
+					name       = "my-first-stack-%s"
+				}
+
+				resource "spacelift_stack" "test2" {
+					branch     = "master"
+					repository = "demo"
+					name       = "my-second-stack-%s"
+				}
+
+				resource "spacelift_stack_dependency" "test" {
+					stack_id = spacelift_stack.test1.id
+					depends_on_stack_id = spacelift_stack.test2.id
+				}
+
+				resource "spacelift_stack_dependency_reference" "test" {
+					stack_dependency_id = spacelift_stack_dependency.test.id
+					output_name = "kubeconfig"
+					input_name = "%s"
+					type = "%s"
+				}`, randomID, randomID, inputName, referenceType)
+		}
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config:      config("/etc/kubeconfig", "FILE_MOUNT"),
+				ExpectError: regexp.MustCompile(`must be a path relative to /mnt/workspace/`),
+			},
+			{
+				Config:      config("source/kubeconfig", "ENVIRONMENT_VARIABLE"),
+				ExpectError: regexp.MustCompile(`is not a valid environment variable name`),
+			},
+			{
+				Config: config("source/kubeconfig", "FILE_MOUNT"),
+				Check: Resource(
+					resourceName,
+					Attribute("input_name", Equals("source/kubeconfig")),
+					Attribute("type", Equals("FILE_MOUNT")),
+				),
+			},
+			{ // updates the type in place
+				Config: config("KUBECONFIG", "ENVIRONMENT_VARIABLE"),
+				Check: Resource(
+					resourceName,
+					Attribute("input_name", Equals("KUBECONFIG")),
+					Attribute("type", Equals("ENVIRONMENT_VARIABLE")),
+				),
+			},
+		})
+	})
 }
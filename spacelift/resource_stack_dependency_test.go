@@ -2,6 +2,7 @@ package spacelift
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -54,4 +55,63 @@ func TestStackDependencyResource(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("rejects dependency cycles", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlpha)
+
+		config := func(withCycle bool) string {
+			cycle := ""
+			if withCycle {
+				cycle = `
+				resource "spacelift_stack_dependency" "cycle" {
+					stack_id            = spacelift_stack.test1.id
+					depends_on_stack_id = spacelift_stack.test3.id
+				}`
+			}
+
+			return fmt.Sprintf(`
+				resource "spacelift_stack" "test1" {
+					branch     = "master"
+					repository = "demo"
+					name       = "cycle-first-%s"
+				}
+
+				resource "spacelift_stack" "test2" {
+					branch     = "master"
+					repository = "demo"
+					name       = "cycle-second-%s"
+				}
+
+				resource "spacelift_stack" "test3" {
+					branch     = "master"
+					repository = "demo"
+					name       = "cycle-third-%s"
+				}
+
+				resource "spacelift_stack_dependency" "second_on_first" {
+					stack_id            = spacelift_stack.test2.id
+					depends_on_stack_id = spacelift_stack.test1.id
+				}
+
+				resource "spacelift_stack_dependency" "third_on_second" {
+					stack_id            = spacelift_stack.test3.id
+					depends_on_stack_id = spacelift_stack.test2.id
+				}
+				%s
+			`, randomID, randomID, randomID, cycle)
+		}
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: config(false),
+			},
+			{
+				Config: config(true),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(
+					"would create a dependency cycle: cycle-first-%[1]s -> cycle-third-%[1]s -> cycle-second-%[1]s -> cycle-first-%[1]s",
+					randomID,
+				)),
+			},
+		})
+	})
 }
@@ -7,11 +7,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
 	"github.com/shurcooL/graphql"
 
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/waiter"
 )
 
 func resourceStackDestructor() *schema.Resource {
@@ -98,7 +100,7 @@ func resourceStackDestructorDelete(ctx context.Context, d *schema.ResourceData,
 	}
 
 	if mutation.DeleteStack != nil && mutation.DeleteStack.Deleting {
-		if diagnostics := waitForDestroy(ctx, meta.(*internal.Client), stackID); diagnostics.HasError() {
+		if diagnostics := waitForDestroy(ctx, meta.(*internal.Client), stackID, d.Timeout(schema.TimeoutDelete)); diagnostics.HasError() {
 			return diagnostics
 		}
 	}
@@ -108,33 +110,43 @@ func resourceStackDestructorDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func waitForDestroy(ctx context.Context, client *internal.Client, id string) diag.Diagnostics {
-	ticker := time.NewTicker(time.Second * 5)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			return diag.FromErr(ctx.Err())
-		case <-ticker.C:
-		}
-
-		var query struct {
-			Stack *structs.Stack `graphql:"stack(id: $id)"`
-		}
-
-		variables := map[string]interface{}{"id": graphql.ID(id)}
-
-		if err := client.Query(ctx, "StackCheckState", &query, variables); err != nil {
-			return diag.Errorf("could not query for stack %s: %v", id, err)
-		}
-
-		stack := query.Stack
-		if stack == nil {
-			return nil
-		}
+func waitForDestroy(ctx context.Context, client *internal.Client, id string, timeout time.Duration) diag.Diagnostics {
+	w := &waiter.Waiter{
+		Name: fmt.Sprintf("destruction of stack %s", id),
+		Refresh: func(ctx context.Context) (string, bool, error) {
+			var query struct {
+				Stack *structs.Stack `graphql:"stack(id: $id)"`
+			}
+
+			variables := map[string]interface{}{"id": graphql.ID(id)}
+
+			if err := client.Query(ctx, "StackCheckState", &query, variables); err != nil {
+				return "", false, errors.Wrapf(err, "could not query for stack %s", id)
+			}
+
+			switch stack := query.Stack; {
+			case stack == nil:
+				return "deleted", true, nil
+			case !stack.Deleting:
+				return "failed", true, nil
+			default:
+				return "deleting", false, nil
+			}
+		},
+		Accepted:    []string{"deleted"},
+		Timeout:     timeout,
+		Delay:       5 * time.Second,
+		MinInterval: 5 * time.Second,
+		MaxInterval: time.Minute,
+	}
 
-		if !stack.Deleting {
-			return diag.Errorf("destruction of stack %s unsuccessful, please check the destruction run logs", id)
-		}
+	_, err := w.Wait(ctx)
+	switch {
+	case err == nil:
+		return nil
+	case internal.IsErrorType[*waiter.UnexpectedStateError](err):
+		return diag.Errorf("destruction of stack %s unsuccessful, please check the destruction run logs", id)
+	default:
+		return diag.FromErr(err)
 	}
 }
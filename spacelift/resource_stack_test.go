@@ -1,7 +1,10 @@
 package spacelift
 
 import (
+	"compress/gzip"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -36,7 +39,7 @@ func TestStackResource(t *testing.T) {
 					before_plan              = ["echo 'before_plan'"]
 					branch                   = "master"
 					description              = "%s"
-					import_state             = "{}"
+					import_state             = jsonencode({ version = 4, lineage = "2c8f2b4f-2a1f-4b7a-9d43-0c7f6d1a6b1e", serial = 1 })
 					labels                   = ["one", "two"]
 					name                     = "Provider test stack %s"
 					project_root             = "root"
@@ -753,6 +756,123 @@ func TestStackResource(t *testing.T) {
 		})
 	})
 
+	t.Run("with a compressed state file import", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		statePath := filepath.Join(t.TempDir(), "terraform.tfstate.gz")
+		writeGzippedState(t, statePath, `{"version": 4, "lineage": "2c8f2b4f-2a1f-4b7a-9d43-0c7f6d1a6b1e", "serial": 3, "resources": []}`)
+
+		config := func(lineage string) string {
+			return fmt.Sprintf(`
+				resource "spacelift_stack" "test" {
+					branch               = "master"
+					import_state_file    = %q
+					import_state_lineage = %q
+					name                 = "Provider test stack %s"
+					repository           = "demo"
+				}
+			`, statePath, lineage, randomID)
+		}
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config:      config("not-the-lineage"),
+				ExpectError: regexp.MustCompile(`imported state has lineage "2c8f2b4f-2a1f-4b7a-9d43-0c7f6d1a6b1e", expected "not-the-lineage"`),
+			},
+			{
+				Config: config("2c8f2b4f-2a1f-4b7a-9d43-0c7f6d1a6b1e"),
+				Check: Resource(
+					resourceName,
+					Attribute("id", StartsWith("provider-test-stack")),
+					Attribute("manage_state", Equals("true")),
+				),
+			},
+		})
+	})
+
+	t.Run("with slug computed at plan time", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_stack" "test" {
+						branch     = "master"
+						name       = "Provider test stack & %s"
+						repository = "demo"
+					}
+
+					resource "spacelift_environment_variable" "test" {
+						for_each = toset(["ONE", "TWO"])
+
+						stack_id = spacelift_stack.test.slug
+						name     = each.key
+						value    = "value"
+					}
+				`, randomID),
+				Check: Resource(
+					resourceName,
+					Attribute("id", Equals("provider-test-stack-and-"+randomID)),
+					Attribute("slug", Equals("provider-test-stack-and-"+randomID)),
+				),
+			},
+		})
+	})
+
+	t.Run("with destroy on delete", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_stack" "test" {
+						branch     = "master"
+						name       = "Provider test stack %s"
+						repository = "demo"
+
+						destroy_on_delete {
+							continue_on_state = ["finished"]
+							cancel_on_timeout = true
+
+							on_unconfirmed {
+								action = "confirm"
+							}
+						}
+
+						timeouts {
+							delete = "30m"
+						}
+					}
+				`, randomID),
+				Check: Resource(
+					resourceName,
+					Attribute("id", StartsWith("provider-test-stack")),
+					SetEquals("destroy_on_delete.0.continue_on_state", "finished"),
+					Attribute("destroy_on_delete.0.cancel_on_timeout", Equals("true")),
+					Attribute("destroy_on_delete.0.allow_remaining_resources", Equals("false")),
+					Attribute("destroy_on_delete.0.on_unconfirmed.0.action", Equals("confirm")),
+				),
+			},
+		})
+	})
+}
+
+func writeGzippedState(t *testing.T, path, content string) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	writer := gzip.NewWriter(file)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
 }
 
 func TestStackResourceSpace(t *testing.T) {
@@ -779,7 +899,7 @@ func TestStackResourceSpace(t *testing.T) {
 					before_plan           = ["echo 'before_plan'"]
 					branch                = "master"
 					description           = "%s"
-					import_state          = "{}"
+					import_state          = jsonencode({ version = 4, lineage = "2c8f2b4f-2a1f-4b7a-9d43-0c7f6d1a6b1e", serial = 1 })
 					labels                = ["one", "two"]
 					name                  = "Provider test stack %s"
 					project_root          = "root"
@@ -0,0 +1,134 @@
+package spacelift
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+func resourceTask() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_task` allows programmatically running arbitrary commands " +
+			"on a stack in response to arbitrary changes in the keepers section.",
+
+		CreateContext: resourceTaskCreate,
+		ReadContext:   resourceTaskRead,
+		Delete:        schema.RemoveFromState,
+		UpdateContext: schema.NoopContext,
+
+		CustomizeDiff: customizeDiffLogsRequireWait,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"stack_id": {
+				Type:             schema.TypeString,
+				Description:      "ID of the stack on which the task is to be run.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"command": {
+				Type:             schema.TypeString,
+				Description:      "Command that will be run.",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"skip_initialization": {
+				Type:        schema.TypeBool,
+				Description: "Whether to skip the initialization phase of the task. Defaults to `false`.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"keepers": {
+				Description: "" +
+					"Arbitrary map of values that, when changed, will trigger " +
+					"recreation of the resource.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"id": {
+				Description: "The ID of the triggered task.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"state": {
+				Description: "The last known state of the task, lowercased (e.g. `finished`, `failed`).",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"wait": waitConfigurationSchema(),
+			"logs": logsConfigurationSchema(),
+		},
+	}
+}
+
+func resourceTaskCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var mutation struct {
+		Task struct {
+			ID string
+		} `graphql:"taskCreate(stack: $stack, command: $command, skipInitialization: $skipInitialization)"`
+	}
+
+	stackID := d.Get("stack_id").(string)
+
+	variables := map[string]interface{}{
+		"stack":              toID(stackID),
+		"command":            toString(d.Get("command")),
+		"skipInitialization": toOptionalBool(d.Get("skip_initialization")),
+	}
+
+	client := meta.(*internal.Client)
+	if err := client.Mutate(ctx, "ResourceTaskCreate", &mutation, variables); err != nil {
+		return diag.Errorf("could not trigger task for stack %s: %v", stackID, internal.FromSpaceliftError(err))
+	}
+
+	var diags diag.Diagnostics
+
+	if waitRaw, ok := d.GetOk("wait"); ok {
+		wait := expandWaitConfiguration(waitRaw.([]interface{}))
+		diags = wait.Wait(ctx, d.Timeout(schema.TimeoutCreate), client, stackID, mutation.Task.ID)
+
+		if logs := expandLogsConfiguration(d.Get("logs").([]interface{})); logs != nil && !wait.disabled {
+			diags = logs.Capture(ctx, client, stackID, mutation.Task.ID, diags)
+		}
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	d.SetId(mutation.Task.ID)
+
+	return append(diags, resourceTaskRead(ctx, d, meta)...)
+}
+
+func resourceTaskRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	stackID := d.Get("stack_id").(string)
+
+	run, err := getRun(ctx, meta.(*internal.Client), stackID, d.Id())
+	if err != nil {
+		return diag.Errorf("could not query for task %s of stack %s: %v", d.Id(), stackID, internal.FromSpaceliftError(err))
+	}
+
+	if run == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("state", strings.ToLower(run.State))
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package spacelift
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestTaskResource(t *testing.T) {
+	t.Run("on a new stack", func(t *testing.T) {
+		const resourceName = "spacelift_task.test"
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+		randomIDwp := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "spacelift_worker_pool" "test" {
+					name        = "Let's create a dummy worker pool to avoid running the job %s"
+				}
+
+				resource "spacelift_stack" "test" {
+					name           = "Test stack %s"
+					repository     = "demo"
+					branch         = "master"
+					worker_pool_id = spacelift_worker_pool.test.id
+				}
+
+				resource "spacelift_task" "test" {
+					stack_id = spacelift_stack.test.id
+					command  = "terraform state list"
+
+					keepers = { "bacon" = "tasty" }
+				}
+			`, randomIDwp, randomID),
+				Check: Resource(
+					resourceName,
+					Attribute("id", IsNotEmpty()),
+					Attribute("stack_id", Contains(randomID)),
+					Attribute("command", Equals("terraform state list")),
+					Attribute("state", IsNotEmpty()),
+				),
+			},
+		})
+	})
+
+	t.Run("wait for the task to finish", func(t *testing.T) {
+		const resourceName = "spacelift_task.test"
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_stack" "test" {
+						name           = "Test stack %s"
+						repository     = "demo"
+						branch         = "feat_wait_for_run"
+					}
+
+					resource "spacelift_task" "test" {
+						stack_id = spacelift_stack.test.id
+						command  = "terraform state list"
+
+						keepers = { "bacon" = "tasty" }
+
+						timeouts {
+							create = "180s"
+						}
+
+						wait {
+							disabled          = false
+							continue_on_state = ["finished", "failed"]
+						}
+					}`, randomID),
+				Check: Resource(
+					resourceName,
+					Attribute("id", IsNotEmpty()),
+					Attribute("state", IsNotEmpty()),
+				),
+			},
+		})
+	})
+}
@@ -0,0 +1,550 @@
+package spacelift
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/graphql"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+)
+
+const terraformProviderVersionRevoked = "REVOKED"
+
+func resourceTerraformProviderVersion() *schema.Resource {
+	return &schema.Resource{
+		Description: "" +
+			"`spacelift_terraform_provider_version` represents a version of a " +
+			"Terraform provider in Spacelift's own provider registry. The platform " +
+			"binaries, checksums and signature are uploaded from local files, " +
+			"usually built with GoReleaser, and the version is then published. " +
+			"Published versions can't be deleted, only revoked, so destroying " +
+			"the resource only removes it from the state unless `revoke_on_destroy` " +
+			"is set.",
+
+		CreateContext: resourceTerraformProviderVersionCreate,
+		ReadContext:   resourceTerraformProviderVersionRead,
+		UpdateContext: resourceTerraformProviderVersionUpdate,
+		DeleteContext: resourceTerraformProviderVersionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTerraformProviderVersionImport,
+		},
+
+		CustomizeDiff: resourceTerraformProviderVersionCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"provider_id": {
+				Type:             schema.TypeString,
+				Description:      "ID of the Terraform provider",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"version": {
+				Type:             schema.TypeString,
+				Description:      "Semantic version number, e.g. `1.2.3`",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"gpg_key_id": {
+				Type:             schema.TypeString,
+				Description:      "ID of the GPG key the SHA256SUMS file is signed with",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"protocols": {
+				Type:        schema.TypeList,
+				Description: "Terraform plugin protocol versions supported by the provider. Defaults to `[\"5.0\"]`.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validations.DisallowEmptyString,
+				},
+			},
+			"platform_files": {
+				Type:        schema.TypeSet,
+				Description: "Paths to the zip archives of the provider binaries, named `<anything>_<os>_<architecture>.zip`, e.g. `terraform-provider-foo_1.2.3_linux_amd64.zip`",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validations.DisallowEmptyString,
+				},
+			},
+			"sha256sums_file": {
+				Type:             schema.TypeString,
+				Description:      "Path to the SHA256SUMS file listing the checksums of all the platform zip archives",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"signature_file": {
+				Type:             schema.TypeString,
+				Description:      "Path to the detached GPG signature of the SHA256SUMS file",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validations.DisallowEmptyString,
+			},
+			"checksums": {
+				Type:        schema.TypeMap,
+				Description: "SHA-256 checksums of the uploaded files, by file name. The version is replaced if any of the files change, and the files aren't needed once it's created.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"revoked": {
+				Type:        schema.TypeBool,
+				Description: "Revoke the version, so that it's no longer offered to users. Revoking a version can't be undone. Default: `false`",
+				Optional:    true,
+				Default:     false,
+			},
+			"revoke_on_destroy": {
+				Type:        schema.TypeBool,
+				Description: "Revoke the version when the resource is destroyed, rather than just removing it from the state. Default: `false`",
+				Optional:    true,
+				Default:     false,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Status of the version, e.g. `ACTIVE` or `REVOKED`",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// providerPlatformArtifact is a zip archive of the provider binary for a
+// single platform.
+type providerPlatformArtifact struct {
+	path         string
+	os           string
+	architecture string
+	checksum     string
+}
+
+// providerVersionArtifacts are the files making up a provider version, checked
+// against each other.
+type providerVersionArtifacts struct {
+	shaSumsPath   string
+	signaturePath string
+	platforms     []providerPlatformArtifact
+	checksums     map[string]interface{}
+}
+
+func readProviderVersionArtifacts(shaSumsPath, signaturePath string, platformPaths []string) (*providerVersionArtifacts, error) {
+	sums, err := parseSHA256Sums(shaSumsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := &providerVersionArtifacts{
+		shaSumsPath:   shaSumsPath,
+		signaturePath: signaturePath,
+		checksums:     make(map[string]interface{}),
+	}
+
+	for _, path := range []string{shaSumsPath, signaturePath} {
+		checksum, err := fileSHA256(path)
+		if err != nil {
+			return nil, err
+		}
+		artifacts.checksums[filepath.Base(path)] = checksum
+	}
+
+	for _, path := range platformPaths {
+		name := filepath.Base(path)
+
+		parts := strings.Split(strings.TrimSuffix(name, ".zip"), "_")
+		if !strings.HasSuffix(name, ".zip") || len(parts) < 3 {
+			return nil, errors.Errorf("could not determine the platform of %s, expected a name like terraform-provider-foo_1.2.3_linux_amd64.zip", path)
+		}
+
+		checksum, err := fileSHA256(path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch expected, ok := sums[name]; {
+		case !ok:
+			return nil, errors.Errorf("%s is not listed in %s", name, shaSumsPath)
+		case expected != checksum:
+			return nil, errors.Errorf("checksum of %s is %s, but %s lists %s", path, checksum, shaSumsPath, expected)
+		}
+
+		artifacts.platforms = append(artifacts.platforms, providerPlatformArtifact{
+			path:         path,
+			os:           parts[len(parts)-2],
+			architecture: parts[len(parts)-1],
+			checksum:     checksum,
+		})
+		artifacts.checksums[name] = checksum
+	}
+
+	return artifacts, nil
+}
+
+// parseSHA256Sums returns the checksums listed in a file in the format of
+// sha256sum, by file name.
+func parseSHA256Sums(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read checksums")
+	}
+	defer file.Close()
+
+	sums := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, errors.Errorf("invalid line in %s: %q", path, scanner.Text())
+		}
+
+		// Binary mode is marked with an asterisk before the file name.
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read checksums")
+	}
+
+	return sums, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read file")
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", errors.Wrapf(err, "could not read %s", path)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func expandProviderVersionArtifacts(d interface{ Get(string) interface{} }) (*providerVersionArtifacts, error) {
+	var platformPaths []string
+	for _, path := range d.Get("platform_files").(*schema.Set).List() {
+		platformPaths = append(platformPaths, path.(string))
+	}
+
+	return readProviderVersionArtifacts(d.Get("sha256sums_file").(string), d.Get("signature_file").(string), platformPaths)
+}
+
+// resourceTerraformProviderVersionCustomizeDiff replaces the version if any
+// of its files change. The files are only required to create the version, so
+// once it exists, they are only checked if present.
+func resourceTerraformProviderVersionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, attribute := range []string{"platform_files", "sha256sums_file", "signature_file"} {
+		if !d.NewValueKnown(attribute) {
+			return nil
+		}
+	}
+
+	artifacts, err := expandProviderVersionArtifacts(d)
+	if err != nil {
+		if d.Id() == "" {
+			return err
+		}
+
+		tflog.Debug(ctx, "not checking provider version files for changes", map[string]any{"error": err.Error()})
+		return nil
+	}
+
+	old, _ := d.GetChange("checksums")
+	if reflect.DeepEqual(old, artifacts.checksums) {
+		return nil
+	}
+
+	if err := d.SetNew("checksums", artifacts.checksums); err != nil {
+		return err
+	}
+
+	// Imported versions have no checksums to compare with.
+	if d.Id() == "" || len(old.(map[string]interface{})) == 0 {
+		return nil
+	}
+
+	return d.ForceNew("checksums")
+}
+
+func resourceTerraformProviderVersionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	artifacts, err := expandProviderVersionArtifacts(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var mutation struct {
+		CreateVersion struct {
+			Version                structs.TerraformProviderVersion `graphql:"version"`
+			SHA256SumsUploadURL    string                           `graphql:"sha256SumsUploadURL"`
+			SignatureFileUploadURL string                           `graphql:"signatureFileUploadURL"`
+		} `graphql:"terraformProviderVersionCreate(provider: $provider, input: $input)"`
+	}
+
+	providerID := d.Get("provider_id").(string)
+
+	input := structs.TerraformProviderVersionInput{
+		GPGKeyID:  toID(d.Get("gpg_key_id")),
+		Protocols: []graphql.String{"5.0"},
+		Version:   toString(d.Get("version")),
+	}
+
+	if protocols := d.Get("protocols").([]interface{}); len(protocols) > 0 {
+		input.Protocols = nil
+		for _, protocol := range protocols {
+			input.Protocols = append(input.Protocols, graphql.String(protocol.(string)))
+		}
+	}
+
+	variables := map[string]interface{}{
+		"provider": toID(providerID),
+		"input":    input,
+	}
+
+	client := meta.(*internal.Client)
+
+	if err := client.Mutate(ctx, "TerraformProviderVersionCreate", &mutation, variables); err != nil {
+		return diag.Errorf("could not create version of Terraform provider %s: %v", providerID, internal.FromSpaceliftError(err))
+	}
+
+	versionID := mutation.CreateVersion.Version.ID
+
+	// Until the version is published, it's a draft which is of no use and
+	// would prevent retrying with the same version number, so any failure
+	// has to clean it up.
+	discardDraft := func(diags diag.Diagnostics) diag.Diagnostics {
+		if err := deleteTerraformProviderVersion(ctx, client, providerID, versionID); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "could not delete draft version",
+				Detail:   internal.FromSpaceliftError(err).Error(),
+			})
+		}
+
+		return diags
+	}
+
+	if err := uploadProviderVersionArtifacts(ctx, client, providerID, versionID, artifacts, mutation.CreateVersion.SHA256SumsUploadURL, mutation.CreateVersion.SignatureFileUploadURL); err != nil {
+		return discardDraft(diag.Errorf("could not upload version %s of Terraform provider %s: %v", versionID, providerID, internal.FromSpaceliftError(err)))
+	}
+
+	if err := publishTerraformProviderVersion(ctx, client, providerID, versionID); err != nil {
+		return discardDraft(diag.Errorf("could not publish version %s of Terraform provider %s: %v", versionID, providerID, internal.FromSpaceliftError(err)))
+	}
+
+	d.SetId(versionID)
+
+	d.Set("checksums", artifacts.checksums)
+
+	if d.Get("revoked").(bool) {
+		if err := revokeTerraformProviderVersion(ctx, client, providerID, versionID); err != nil {
+			return append(diag.Errorf("could not revoke version %s of Terraform provider %s: %v", versionID, providerID, internal.FromSpaceliftError(err)), resourceTerraformProviderVersionRead(ctx, d, meta)...)
+		}
+	}
+
+	return resourceTerraformProviderVersionRead(ctx, d, meta)
+}
+
+func uploadProviderVersionArtifacts(ctx context.Context, client *internal.Client, providerID, versionID string, artifacts *providerVersionArtifacts, shaSumsURL, signatureURL string) error {
+	if err := uploadFile(ctx, client, shaSumsURL, artifacts.shaSumsPath, "text/plain"); err != nil {
+		return err
+	}
+
+	if err := uploadFile(ctx, client, signatureURL, artifacts.signaturePath, "application/octet-stream"); err != nil {
+		return err
+	}
+
+	for _, platform := range artifacts.platforms {
+		var mutation struct {
+			UploadURL string `graphql:"terraformProviderVersionRegisterPlatform(provider: $provider, version: $version, input: $input)"`
+		}
+
+		variables := map[string]interface{}{
+			"provider": toID(providerID),
+			"version":  toID(versionID),
+			"input": structs.TerraformProviderVersionPlatformInput{
+				Architecture: graphql.String(platform.architecture),
+				BinarySHA256: graphql.String(platform.checksum),
+				OS:           graphql.String(platform.os),
+			},
+		}
+
+		if err := client.Mutate(ctx, "TerraformProviderVersionRegisterPlatform", &mutation, variables); err != nil {
+			return errors.Wrapf(err, "could not register platform %s_%s", platform.os, platform.architecture)
+		}
+
+		// The file may have changed since it was checked.
+		if checksum, err := fileSHA256(platform.path); err != nil {
+			return err
+		} else if checksum != platform.checksum {
+			return errors.Errorf("%s has changed since its checksum was verified", platform.path)
+		}
+
+		if err := uploadFile(ctx, client, mutation.UploadURL, platform.path, "application/zip"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func uploadFile(ctx context.Context, client *internal.Client, url, path, contentType string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrap(err, "could not read file")
+	}
+
+	tflog.Debug(ctx, "uploading file", map[string]interface{}{
+		"path": path,
+		"size": info.Size(),
+	})
+
+	body := func() (io.Reader, error) { return os.Open(path) }
+
+	return errors.Wrapf(client.Upload(ctx, url, body, info.Size(), contentType), "could not upload %s", path)
+}
+
+func resourceTerraformProviderVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var query struct {
+		TerraformProvider *struct {
+			Version *structs.TerraformProviderVersion `graphql:"version(id: $version)"`
+		} `graphql:"terraformProvider(id: $provider)"`
+	}
+
+	variables := map[string]interface{}{
+		"provider": toID(d.Get("provider_id")),
+		"version":  toID(d.Id()),
+	}
+
+	if err := meta.(*internal.Client).Query(ctx, "TerraformProviderVersionRead", &query, variables); err != nil {
+		return diag.Errorf("could not query for Terraform provider version: %v", internal.FromSpaceliftError(err))
+	}
+
+	if query.TerraformProvider == nil || query.TerraformProvider.Version == nil {
+		d.SetId("")
+		return nil
+	}
+
+	version := query.TerraformProvider.Version
+
+	d.Set("version", version.Number)
+	d.Set("protocols", version.Protocols)
+	d.Set("status", version.Status)
+	d.Set("revoked", version.Status == terraformProviderVersionRevoked)
+
+	return nil
+}
+
+func resourceTerraformProviderVersionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("revoked") {
+		return resourceTerraformProviderVersionRead(ctx, d, meta)
+	}
+
+	providerID := d.Get("provider_id").(string)
+
+	if !d.Get("revoked").(bool) {
+		return diag.Errorf("version %s of Terraform provider %s has been revoked, which can't be undone", d.Id(), providerID)
+	}
+
+	var ret diag.Diagnostics
+
+	if err := revokeTerraformProviderVersion(ctx, meta.(*internal.Client), providerID, d.Id()); err != nil {
+		ret = diag.Errorf("could not revoke version %s of Terraform provider %s: %v", d.Id(), providerID, internal.FromSpaceliftError(err))
+	}
+
+	return append(ret, resourceTerraformProviderVersionRead(ctx, d, meta)...)
+}
+
+func resourceTerraformProviderVersionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("revoke_on_destroy").(bool) && !d.Get("revoked").(bool) {
+		providerID := d.Get("provider_id").(string)
+
+		if err := revokeTerraformProviderVersion(ctx, meta.(*internal.Client), providerID, d.Id()); err != nil {
+			return diag.Errorf("could not revoke version %s of Terraform provider %s: %v", d.Id(), providerID, internal.FromSpaceliftError(err))
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceTerraformProviderVersionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	providerID, versionID, ok := strings.Cut(d.Id(), "/")
+	if !ok || providerID == "" || versionID == "" {
+		return nil, errors.Errorf("unexpected ID %q, expected provider_id/version_id", d.Id())
+	}
+
+	d.Set("provider_id", providerID)
+	d.SetId(versionID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func publishTerraformProviderVersion(ctx context.Context, client *internal.Client, providerID, versionID string) error {
+	var mutation struct {
+		Version structs.TerraformProviderVersion `graphql:"terraformProviderVersionPublish(provider: $provider, version: $version)"`
+	}
+
+	variables := map[string]interface{}{
+		"provider": toID(providerID),
+		"version":  toID(versionID),
+	}
+
+	return client.Mutate(ctx, "TerraformProviderVersionPublish", &mutation, variables)
+}
+
+func revokeTerraformProviderVersion(ctx context.Context, client *internal.Client, providerID, versionID string) error {
+	var mutation struct {
+		Version structs.TerraformProviderVersion `graphql:"terraformProviderVersionRevoke(provider: $provider, version: $version)"`
+	}
+
+	variables := map[string]interface{}{
+		"provider": toID(providerID),
+		"version":  toID(versionID),
+	}
+
+	return client.Mutate(ctx, "TerraformProviderVersionRevoke", &mutation, variables)
+}
+
+func deleteTerraformProviderVersion(ctx context.Context, client *internal.Client, providerID, versionID string) error {
+	var mutation struct {
+		Version *structs.TerraformProviderVersion `graphql:"terraformProviderVersionDelete(provider: $provider, version: $version)"`
+	}
+
+	variables := map[string]interface{}{
+		"provider": toID(providerID),
+		"version":  toID(versionID),
+	}
+
+	return client.Mutate(ctx, "TerraformProviderVersionDelete", &mutation, variables)
+}
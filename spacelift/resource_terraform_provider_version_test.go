@@ -0,0 +1,185 @@
+package spacelift
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
+)
+
+func TestTerraformProviderVersionResource(t *testing.T) {
+	t.Run("verifies checksums at plan time", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlpha)
+
+		dir := t.TempDir()
+		files := map[string]string{
+			"terraform-provider-test_1.0.0_linux_amd64.zip": "not really a zip",
+			"terraform-provider-test_1.0.0_SHA256SUMS":      fmt.Sprintf("%064d  terraform-provider-test_1.0.0_linux_amd64.zip\n", 0),
+			"terraform-provider-test_1.0.0_SHA256SUMS.sig":  "not really a signature",
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "spacelift_terraform_provider" "test" {
+						type     = "%s"
+						space_id = "root"
+					}
+
+					resource "spacelift_terraform_provider_version" "test" {
+						provider_id     = spacelift_terraform_provider.test.id
+						version         = "1.0.0"
+						gpg_key_id      = "does-not-matter"
+						platform_files  = ["%[2]s/terraform-provider-test_1.0.0_linux_amd64.zip"]
+						sha256sums_file = "%[2]s/terraform-provider-test_1.0.0_SHA256SUMS"
+						signature_file  = "%[2]s/terraform-provider-test_1.0.0_SHA256SUMS.sig"
+					}
+				`, randomID, dir),
+				ExpectError: regexp.MustCompile(`checksum of .*linux_amd64.zip is [0-9a-f]{64}, but .* lists 0{64}`),
+			},
+		})
+	})
+
+	t.Run("publishes a version", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlpha)
+		asciiArmor, dir := testProviderVersionFiles(t, "1.0.0")
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: testProviderVersionConfig(randomID, asciiArmor, dir, true),
+				Check: Resource(
+					"spacelift_terraform_provider_version.test",
+					Attribute("id", IsNotEmpty()),
+					Attribute("version", Equals("1.0.0")),
+					Attribute("status", Equals("ACTIVE")),
+					Attribute("revoked", Equals("false")),
+					Attribute("checksums.%", Equals("3")),
+				),
+			},
+		})
+	})
+
+	t.Run("revokes on destroy", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlpha)
+		asciiArmor, dir := testProviderVersionFiles(t, "1.0.0")
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: testProviderVersionConfig(randomID, asciiArmor, dir, true),
+				Check: Resource(
+					"spacelift_terraform_provider_version.test",
+					Attribute("revoke_on_destroy", Equals("true")),
+				),
+			},
+			{
+				Config: testProviderVersionConfig(randomID, asciiArmor, dir, false),
+			},
+			{
+				Config: testProviderVersionConfig(randomID, asciiArmor, dir, false),
+				Check: Resource(
+					"data.spacelift_terraform_provider.test",
+					Attribute("versions.#", Equals("1")),
+					Nested("versions", CheckInList(Attribute("status", Equals("REVOKED")))),
+				),
+			},
+		})
+	})
+}
+
+// testProviderVersionConfig declares a provider and a GPG key and, if
+// withVersion is set, a version of the provider which is revoked on destroy.
+func testProviderVersionConfig(randomID, asciiArmor, dir string, withVersion bool) string {
+	config := fmt.Sprintf(`
+		resource "spacelift_terraform_provider" "test" {
+			type     = "%s"
+			space_id = "root"
+		}
+
+		resource "spacelift_gpg_key" "test" {
+			name        = "provider-test-key-%[1]s"
+			ascii_armor = <<EOF
+%[2]sEOF
+		}
+
+		data "spacelift_terraform_provider" "test" {
+			type = spacelift_terraform_provider.test.id
+		}
+	`, randomID, asciiArmor)
+
+	if !withVersion {
+		return config
+	}
+
+	return config + fmt.Sprintf(`
+		resource "spacelift_terraform_provider_version" "test" {
+			provider_id       = spacelift_terraform_provider.test.id
+			version           = "1.0.0"
+			gpg_key_id        = spacelift_gpg_key.test.id
+			platform_files    = ["%[1]s/terraform-provider-test_1.0.0_linux_amd64.zip"]
+			sha256sums_file   = "%[1]s/terraform-provider-test_1.0.0_SHA256SUMS"
+			signature_file    = "%[1]s/terraform-provider-test_1.0.0_SHA256SUMS.sig"
+			revoke_on_destroy = true
+		}
+	`, dir)
+}
+
+// testProviderVersionFiles writes the platform archive, SHA256SUMS and its
+// signature for a version of a test provider, and returns the public key the
+// signature can be verified with along with the directory of the files.
+func testProviderVersionFiles(t *testing.T, version string) (asciiArmor, dir string) {
+	t.Helper()
+
+	dir = t.TempDir()
+	prefix := fmt.Sprintf("terraform-provider-test_%s", version)
+
+	var archive bytes.Buffer
+
+	writer := zip.NewWriter(&archive)
+	file, err := writer.Create(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Write([]byte("not really a binary")); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zipName := prefix + "_linux_amd64.zip"
+	shaSums := fmt.Sprintf("%x  %s\n", sha256.Sum256(archive.Bytes()), zipName)
+
+	entity := testGPGEntity(t)
+
+	var signature bytes.Buffer
+	if err := openpgp.DetachSign(&signature, entity, bytes.NewReader([]byte(shaSums)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range map[string][]byte{
+		zipName:                    archive.Bytes(),
+		prefix + "_SHA256SUMS":     []byte(shaSums),
+		prefix + "_SHA256SUMS.sig": signature.Bytes(),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return testGPGKeyArmor(t, entity), dir
+}
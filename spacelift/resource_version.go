@@ -2,25 +2,45 @@ package spacelift
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
 	"github.com/shurcooL/graphql"
 
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
 	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/validations"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/waiter"
 )
 
 func resourceVersion() *schema.Resource {
+	versionFields := moduleVersionFields()
+
 	return &schema.Resource{
 		Description: "" +
 			"`spacelift_version` allows to programmatically trigger a module version creation " +
-			"in response to arbitrary changes in the keepers section.",
+			"in response to arbitrary changes in the keepers section. Versions can be yanked " +
+			"to prevent their further use.",
 
 		CreateContext: resourceVersionCreate,
-		ReadContext:   schema.NoopContext,
-		Delete:        schema.RemoveFromState,
+		ReadContext:   resourceVersionRead,
+		UpdateContext: resourceVersionUpdate,
+		DeleteContext: resourceVersionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVersionImport,
+		},
+
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.Get("deprecation_reason").(string) != "" && !d.Get("yanked").(bool) {
+				return errors.New(`"deprecation_reason" requires "yanked" to be true`)
+			}
+			return nil
+		},
 
 		Schema: map[string]*schema.Schema{
 			"module_id": {
@@ -42,6 +62,33 @@ func resourceVersion() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"resolved_commit_sha": {
+				Description: "The full commit SHA the version was created from.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"resolved_version_number": {
+				Description: "The version number assigned to the version.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"yanked": {
+				Description: "Whether the version is yanked, preventing its further use. Default: `false`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"deprecation_reason": {
+				Description: "Reason for yanking the version, shown to its users. Requires `yanked` to be `true`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"yank_on_destroy": {
+				Description: "Yank the version when the resource is destroyed, rather than just removing it from the state. Default: `false`",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"keepers": {
 				Description: "" +
 					"Arbitrary map of values that, when changed, will trigger " +
@@ -55,6 +102,15 @@ func resourceVersion() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"state":      versionFields["state"],
+			"created_at": versionFields["created_at"],
+			"inputs":     versionFields["inputs"],
+			"outputs":    versionFields["outputs"],
+			"providers":  versionFields["providers"],
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
 		},
 	}
 }
@@ -87,7 +143,7 @@ func resourceVersionCreate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	if mutation.Version.ID != "" {
-		diag := waitForVersionCreate(ctx, meta.(*internal.Client), mutation.Version.ID, moduleID.(string))
+		diag := waitForVersionCreate(ctx, meta.(*internal.Client), mutation.Version.ID, moduleID.(string), d.Timeout(schema.TimeoutCreate))
 		if diag.HasError() {
 			return diag
 		}
@@ -95,45 +151,198 @@ func resourceVersionCreate(ctx context.Context, d *schema.ResourceData, meta int
 
 	d.SetId(mutation.Version.ID)
 
-	return nil
+	if d.Get("yanked").(bool) {
+		if err := yankVersion(ctx, meta.(*internal.Client), moduleID.(string), d.Id(), d.Get("deprecation_reason").(string)); err != nil {
+			return append(diag.FromErr(err), resourceVersionRead(ctx, d, meta)...)
+		}
+	}
+
+	return resourceVersionRead(ctx, d, meta)
 }
 
-func waitForVersionCreate(ctx context.Context, client *internal.Client, versionID, moduleID string) diag.Diagnostics {
-	ticker := time.NewTicker(time.Second * 5)
-	defer ticker.Stop()
+func resourceVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var query struct {
+		Module *struct {
+			Version *structs.Version `graphql:"version(id: $versionId)"`
+		} `graphql:"module(id: $moduleId)"`
+	}
 
 	variables := map[string]interface{}{
-		"moduleId":  graphql.ID(moduleID),
-		"versionId": graphql.ID(versionID),
+		"moduleId":  toID(d.Get("module_id")),
+		"versionId": toID(d.Id()),
 	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return diag.FromErr(ctx.Err())
-		case <-ticker.C:
-		}
+	if err := meta.(*internal.Client).Query(ctx, "VersionRead", &query, variables); err != nil {
+		return diag.Errorf("could not query for module version: %v", internal.FromSpaceliftError(err))
+	}
+
+	if query.Module == nil || query.Module.Version == nil {
+		d.SetId("")
+		return nil
+	}
 
-		var query struct {
-			Module struct {
-				Version struct {
-					State string `graphql:"state"`
-				} `graphql:"version(id: $versionId)"`
-			} `graphql:"module(id: $moduleId)"`
+	version := flattenModuleVersion(query.Module.Version)
+
+	d.Set("resolved_commit_sha", version["commit_sha"])
+	d.Set("resolved_version_number", version["number"])
+	d.Set("state", version["state"])
+	d.Set("created_at", version["created_at"])
+	d.Set("yanked", version["yanked"])
+
+	if note := query.Module.Version.YankNote; note != nil && query.Module.Version.Yanked {
+		d.Set("deprecation_reason", *note)
+	} else {
+		d.Set("deprecation_reason", nil)
+	}
+
+	for _, field := range []string{"inputs", "outputs", "providers"} {
+		if err := d.Set(field, version[field]); err != nil {
+			return diag.Errorf("could not set %s: %v", field, err)
 		}
+	}
 
-		if err := client.Query(ctx, "GetVersion", &query, variables); err != nil {
-			return diag.Errorf("could not query for module %q with version %q: %v", moduleID, versionID, err)
+	return nil
+}
+
+func resourceVersionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*internal.Client)
+	moduleID := d.Get("module_id").(string)
+
+	var err error
+	switch yanked := d.Get("yanked").(bool); {
+	case yanked && (d.HasChange("yanked") || d.HasChange("deprecation_reason")):
+		err = yankVersion(ctx, client, moduleID, d.Id(), d.Get("deprecation_reason").(string))
+	case !yanked && d.HasChange("yanked"):
+		err = unyankVersion(ctx, client, moduleID, d.Id())
+	}
+
+	var ret diag.Diagnostics
+	if err != nil {
+		ret = diag.FromErr(err)
+	}
+
+	return append(ret, resourceVersionRead(ctx, d, meta)...)
+}
+
+func resourceVersionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("yank_on_destroy").(bool) && !d.Get("yanked").(bool) {
+		if err := yankVersion(ctx, meta.(*internal.Client), d.Get("module_id").(string), d.Id(), d.Get("deprecation_reason").(string)); err != nil {
+			return diag.FromErr(err)
 		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceVersionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	moduleID, versionID, ok := strings.Cut(d.Id(), "/")
+	if !ok || moduleID == "" || versionID == "" {
+		return nil, errors.Errorf("unexpected ID %q, expected module_id/version_id", d.Id())
+	}
+
+	d.Set("module_id", moduleID)
+	d.SetId(versionID)
+
+	// The version number is usually part of the configuration, so let's import
+	// it to avoid replacing the version on the next plan. The commit SHA isn't
+	// imported, as it's rarely set and may be a short one.
+	if diags := resourceVersionRead(ctx, d, meta); diags.HasError() {
+		return nil, errors.New(diags[0].Summary)
+	}
+
+	if d.Id() == "" {
+		return nil, errors.Errorf("version %s of module %s not found", versionID, moduleID)
+	}
+
+	d.Set("version_number", d.Get("resolved_version_number"))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func yankVersion(ctx context.Context, client *internal.Client, moduleID, versionID, note string) error {
+	var mutation struct {
+		Version struct {
+			ID string `graphql:"id"`
+		} `graphql:"versionYank(module: $module, id: $id, note: $note)"`
+	}
+
+	variables := map[string]interface{}{
+		"module": toID(moduleID),
+		"id":     toID(versionID),
+		"note":   (*graphql.String)(nil),
+	}
+
+	if note != "" {
+		variables["note"] = toOptionalString(note)
+	}
+
+	if err := client.Mutate(ctx, "VersionYank", &mutation, variables); err != nil {
+		return errors.Errorf("could not yank version %s of module %s: %v", versionID, moduleID, internal.FromSpaceliftError(err))
+	}
+
+	return nil
+}
+
+func unyankVersion(ctx context.Context, client *internal.Client, moduleID, versionID string) error {
+	var mutation struct {
+		Version struct {
+			ID string `graphql:"id"`
+		} `graphql:"versionUnyank(module: $module, id: $id)"`
+	}
+
+	variables := map[string]interface{}{
+		"module": toID(moduleID),
+		"id":     toID(versionID),
+	}
+
+	if err := client.Mutate(ctx, "VersionUnyank", &mutation, variables); err != nil {
+		return errors.Errorf("could not unyank version %s of module %s: %v", versionID, moduleID, internal.FromSpaceliftError(err))
+	}
+
+	return nil
+}
+
+func waitForVersionCreate(ctx context.Context, client *internal.Client, versionID, moduleID string, timeout time.Duration) diag.Diagnostics {
+	variables := map[string]interface{}{
+		"moduleId":  graphql.ID(moduleID),
+		"versionId": graphql.ID(versionID),
+	}
+
+	w := &waiter.Waiter{
+		Name: fmt.Sprintf("module %q version %q", moduleID, versionID),
+		Refresh: func(ctx context.Context) (string, bool, error) {
+			var query struct {
+				Module struct {
+					Version struct {
+						State string `graphql:"state"`
+					} `graphql:"version(id: $versionId)"`
+				} `graphql:"module(id: $moduleId)"`
+			}
+
+			if err := client.Query(ctx, "GetVersion", &query, variables); err != nil {
+				return "", false, errors.Wrapf(err, "could not query for module %q with version %q", moduleID, versionID)
+			}
 
-		switch query.Module.Version.State {
-		case "ACTIVE":
-			return nil
-		case "FAILED":
-			return diag.Errorf("module %q version %q creation failed, please check the run logs for more information", moduleID, versionID)
-		default:
 			// We wait if module version is in any other state.
-			continue
-		}
+			return query.Module.Version.State, false, nil
+		},
+		Accepted:    []string{"ACTIVE"},
+		Terminal:    []string{"FAILED"},
+		Timeout:     timeout,
+		Delay:       5 * time.Second,
+		MinInterval: 5 * time.Second,
+		MaxInterval: 30 * time.Second,
+	}
+
+	_, err := w.Wait(ctx)
+	switch {
+	case err == nil:
+		return nil
+	case internal.IsErrorType[*waiter.UnexpectedStateError](err):
+		return diag.Errorf("module %q version %q creation failed, please check the run logs for more information", moduleID, versionID)
+	default:
+		return diag.FromErr(err)
 	}
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	. "github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/testhelpers"
 )
@@ -42,4 +43,68 @@ func TestVersionResource(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("imports and yanks a version", func(t *testing.T) {
+		const resourceName = "spacelift_version.test"
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		config := func(yanked bool) string {
+			return fmt.Sprintf(`
+				resource "spacelift_module" "test" {
+					name           = "test-yank-module-%s"
+					administrative = true
+					branch         = "module"
+					repository     = "terraform-bacon-tasty"
+				}
+
+				resource "spacelift_version" "test" {
+					module_id          = spacelift_module.test.id
+					version_number     = "0.2.0"
+					yanked             = %t
+					deprecation_reason = %s
+				}
+			`, randomID, yanked, map[bool]string{true: `"broken"`, false: "null"}[yanked])
+		}
+
+		testSteps(t, []resource.TestStep{
+			{
+				Config: config(false),
+				Check: Resource(
+					resourceName,
+					Attribute("state", Equals("ACTIVE")),
+					Attribute("version_number", Equals("0.2.0")),
+					Attribute("resolved_version_number", Equals("0.2.0")),
+					Attribute("resolved_commit_sha", IsNotEmpty()),
+					AttributeNotPresent("commit_sha"),
+					Attribute("yanked", Equals("false")),
+				),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					version := state.RootModule().Resources[resourceName].Primary
+					return fmt.Sprintf("%s/%s", version.Attributes["module_id"], version.ID), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"yank_on_destroy"},
+			},
+			{
+				Config: config(true),
+				Check: Resource(
+					resourceName,
+					Attribute("yanked", Equals("true")),
+					Attribute("deprecation_reason", Equals("broken")),
+				),
+			},
+			{
+				Config: config(false),
+				Check: Resource(
+					resourceName,
+					Attribute("yanked", Equals("false")),
+				),
+			},
+		})
+	})
 }
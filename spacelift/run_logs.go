@@ -0,0 +1,257 @@
+package spacelift
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/graphql"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+)
+
+const (
+	logPhasesFailed = "failed"
+	logPhasesAll    = "all"
+)
+
+func logsConfigurationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Capture the logs of the run once waiting for it has ended. Requires `wait` to be enabled.",
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"phases": {
+					Type:         schema.TypeString,
+					Description:  "Which phases of the run to fetch the logs for: `failed` for the last phase only, or `all`. Default: `failed`",
+					Optional:     true,
+					Default:      logPhasesFailed,
+					ValidateFunc: validation.StringInSlice([]string{logPhasesFailed, logPhasesAll}, false),
+				},
+				"tail_lines": {
+					Type:         schema.TypeInt,
+					Description:  "Number of trailing log lines to include in the error if the run did not end as expected. `0` disables it. Default: `20`",
+					Optional:     true,
+					Default:      20,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"output_path": {
+					Type:        schema.TypeString,
+					Description: "Local path to write the full log of the fetched phases to, whether the run succeeded or not.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+// customizeDiffLogsRequireWait rejects logs without an enabled wait, since the
+// logs are only captured once waiting for the run has ended.
+func customizeDiffLogsRequireWait(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if len(d.Get("logs").([]interface{})) == 0 {
+		return nil
+	}
+
+	wait := d.Get("wait").([]interface{})
+	if len(wait) == 0 {
+		return errors.New("logs can only be captured if wait is set")
+	}
+
+	if waitConfig, ok := wait[0].(map[string]interface{}); ok && waitConfig["disabled"].(bool) {
+		return errors.New("logs can't be captured if wait is disabled")
+	}
+
+	return nil
+}
+
+type logsConfiguration struct {
+	phases     string
+	tailLines  int
+	outputPath string
+}
+
+func expandLogsConfiguration(input []interface{}) *logsConfiguration {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	v := input[0].(map[string]interface{})
+	return &logsConfiguration{
+		phases:     v["phases"].(string),
+		tailLines:  v["tail_lines"].(int),
+		outputPath: v["output_path"].(string),
+	}
+}
+
+type runPhaseLogs struct {
+	phase string
+	lines []string
+}
+
+// Capture fetches the logs of the run and handles them according to the
+// configuration. Diagnostics from waiting for the run are passed in so that an
+// error can be extended with the tail of the log.
+func (cfg *logsConfiguration) Capture(ctx context.Context, client *internal.Client, stackID, runID string, diags diag.Diagnostics) diag.Diagnostics {
+	logs, err := getRunLogs(ctx, client, stackID, runID, cfg.phases == logPhasesAll)
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("could not fetch logs for run %s on stack %s", runID, stackID),
+			Detail:   internal.FromSpaceliftError(err).Error(),
+		})
+	}
+
+	if cfg.outputPath != "" {
+		if err := writeRunLogs(cfg.outputPath, logs); err != nil {
+			return append(diags, diag.Errorf("could not write logs for run %s on stack %s: %v", runID, stackID, err)...)
+		}
+		tflog.Debug(ctx, "wrote run logs", map[string]any{
+			"stackID": stackID,
+			"runID":   runID,
+			"path":    cfg.outputPath,
+		})
+	}
+
+	if cfg.tailLines == 0 || !diags.HasError() || len(logs) == 0 {
+		return diags
+	}
+
+	last := logs[len(logs)-1]
+	tail := last.lines
+	if len(tail) > cfg.tailLines {
+		tail = tail[len(tail)-cfg.tailLines:]
+	}
+
+	for i := len(diags) - 1; i >= 0; i-- {
+		if diags[i].Severity != diag.Error {
+			continue
+		}
+		detail := fmt.Sprintf("Last %d lines of the %s phase log:\n\n%s", len(tail), last.phase, strings.Join(tail, "\n"))
+		if diags[i].Detail != "" {
+			detail = diags[i].Detail + "\n\n" + detail
+		}
+		diags[i].Detail = detail
+		break
+	}
+
+	return diags
+}
+
+// getRunLogs returns the logs of the phases of the run which have them, in
+// chronological order. Unless all is set, only the last such phase is fetched.
+func getRunLogs(ctx context.Context, client *internal.Client, stackID, runID string, all bool) ([]runPhaseLogs, error) {
+	var query struct {
+		Stack *struct {
+			Run *struct {
+				History []struct {
+					HasLogs bool   `graphql:"hasLogs"`
+					State   string `graphql:"state"`
+				} `graphql:"history"`
+			} `graphql:"run(id: $runId)"`
+		} `graphql:"stack(id: $stackId)"`
+	}
+
+	variables := map[string]interface{}{
+		"stackId": toID(stackID),
+		"runId":   toID(runID),
+	}
+
+	if err := client.Query(ctx, "RunLogsHistory", &query, variables); err != nil {
+		return nil, err
+	}
+
+	if query.Stack == nil || query.Stack.Run == nil {
+		return nil, errors.Errorf("run %s not found", runID)
+	}
+
+	// History is ordered from the most recent state to the oldest one.
+	var phases []string
+	for _, entry := range query.Stack.Run.History {
+		if !entry.HasLogs {
+			continue
+		}
+		phases = append([]string{entry.State}, phases...)
+		if !all {
+			break
+		}
+	}
+
+	logs := make([]runPhaseLogs, 0, len(phases))
+	for _, phase := range phases {
+		lines, err := getRunPhaseLogs(ctx, client, stackID, runID, phase)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not fetch logs of the %s phase", phase)
+		}
+		logs = append(logs, runPhaseLogs{phase: strings.ToLower(phase), lines: lines})
+	}
+
+	return logs, nil
+}
+
+func getRunPhaseLogs(ctx context.Context, client *internal.Client, stackID, runID, phase string) ([]string, error) {
+	var lines []string
+	var token *graphql.String
+
+	for {
+		var query struct {
+			Stack *struct {
+				Run *struct {
+					Logs *struct {
+						HasMore  bool `graphql:"hasMore"`
+						Messages []struct {
+							Message string `graphql:"message"`
+						} `graphql:"messages"`
+						NextToken *graphql.String `graphql:"nextToken"`
+					} `graphql:"logs(state: $state, token: $token)"`
+				} `graphql:"run(id: $runId)"`
+			} `graphql:"stack(id: $stackId)"`
+		}
+
+		variables := map[string]interface{}{
+			"stackId": toID(stackID),
+			"runId":   toID(runID),
+			"state":   structs.RunState(phase),
+			"token":   token,
+		}
+
+		if err := client.Query(ctx, "RunLogs", &query, variables); err != nil {
+			return nil, err
+		}
+
+		if query.Stack == nil || query.Stack.Run == nil || query.Stack.Run.Logs == nil {
+			return lines, nil
+		}
+
+		for _, message := range query.Stack.Run.Logs.Messages {
+			lines = append(lines, strings.Split(strings.TrimRight(message.Message, "\n"), "\n")...)
+		}
+
+		if !query.Stack.Run.Logs.HasMore || query.Stack.Run.Logs.NextToken == nil {
+			return lines, nil
+		}
+
+		token = query.Stack.Run.Logs.NextToken
+	}
+}
+
+func writeRunLogs(path string, logs []runPhaseLogs) error {
+	var sb strings.Builder
+
+	for _, phase := range logs {
+		fmt.Fprintf(&sb, "=== %s ===\n", strings.ToUpper(phase.phase))
+		for _, line := range phase.lines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
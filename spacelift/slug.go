@@ -0,0 +1,78 @@
+package spacelift
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// slugSubstitutions are the characters which are spelled out in slugs rather
+// than replaced with dashes.
+var slugSubstitutions = strings.NewReplacer("&", " and ", "@", " at ")
+
+// computeSlug returns the ID the server derives from a name: the lowercased
+// name with any run of characters other than letters, digits and underscores
+// replaced with a single dash. Names with non-ASCII characters are
+// transliterated by the server in ways we don't reproduce, so no slug is
+// returned for them.
+func computeSlug(name string) (string, bool) {
+	var sb strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(slugSubstitutions.Replace(name)) {
+		switch {
+		case r > 127:
+			return "", false
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			if dash && sb.Len() > 0 {
+				sb.WriteRune('-')
+			}
+			dash = false
+			sb.WriteRune(r)
+		default:
+			dash = true
+		}
+	}
+
+	slug := strings.Trim(sb.String(), "-_")
+
+	return slug, slug != ""
+}
+
+// planSlug sets the attribute to the slug computed from the name when a new
+// resource is planned without an explicit one, so that references to it are
+// known at plan time.
+func planSlug(d *schema.ResourceDiff, attribute string) error {
+	if d.Id() != "" || d.NewValueKnown(attribute) || !d.NewValueKnown("name") {
+		return nil
+	}
+
+	if slug, ok := computeSlug(d.Get("name").(string)); ok {
+		return d.SetNew(attribute, slug)
+	}
+
+	return nil
+}
+
+// checkSlug warns about the server assigning a resource an ID different from
+// the slug computed at plan time. The resource itself was created correctly and
+// Read stores the actual slug, so this must not fail the apply: that would
+// taint the resource and have it destroyed on the next one.
+func checkSlug(kind, planned, actual string) diag.Diagnostics {
+	if planned == "" || planned == actual {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s was created with ID %q instead of the planned %q", kind, actual, planned),
+		Detail: fmt.Sprintf(
+			"The ID of the %s was computed from its name at plan time as %q, but the server assigned %q. "+
+				"The state now holds the actual slug, but anything planned with the computed one may need another apply. "+
+				"Please report the name of the %s.",
+			kind, planned, actual, kind,
+		),
+	}}
+}
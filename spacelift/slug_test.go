@@ -0,0 +1,31 @@
+package spacelift
+
+import "testing"
+
+func Test_computeSlug(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "My Stack", want: "my-stack", wantOK: true},
+		{name: "Stack 42", want: "stack-42", wantOK: true},
+		{name: "snake_case_name", want: "snake_case_name", wantOK: true},
+		{name: "a -- b..c", want: "a-b-c", wantOK: true},
+		{name: "  Leading and trailing!! ", want: "leading-and-trailing", wantOK: true},
+		{name: "_private_", want: "private", wantOK: true},
+		{name: "R&D @ home", want: "r-and-d-at-home", wantOK: true},
+		{name: "Zürich", want: "", wantOK: false},
+		{name: "!!!", want: "", wantOK: false},
+		{name: "", want: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := computeSlug(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("computeSlug(%q) = (%q, %t), want (%q, %t)", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
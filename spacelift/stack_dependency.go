@@ -0,0 +1,78 @@
+package spacelift
+
+import (
+	"context"
+
+	"github.com/shurcooL/graphql"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+)
+
+// The mutations below are shared by spacelift_stack_dependency,
+// spacelift_stack_dependency_reference and spacelift_stack_dependencies.
+
+func createStackDependency(ctx context.Context, client *internal.Client, input structs.StackDependencyInput) (*structs.StackDependency, error) {
+	var mutation struct {
+		StackDependency structs.StackDependency `graphql:"stackDependencyCreate(input: $input)"`
+	}
+
+	variables := map[string]interface{}{"input": input}
+
+	if err := client.Mutate(ctx, "StackDependencyCreate", &mutation, variables); err != nil {
+		return nil, err
+	}
+
+	return &mutation.StackDependency, nil
+}
+
+func deleteStackDependency(ctx context.Context, client *internal.Client, dependencyID graphql.ID) error {
+	var mutation struct {
+		StackDependency *structs.StackDependency `graphql:"stackDependencyDelete(id: $id)"`
+	}
+
+	variables := map[string]interface{}{"id": dependencyID}
+
+	return client.Mutate(ctx, "StackDependencyDelete", &mutation, variables)
+}
+
+func addStackDependencyReference(ctx context.Context, client *internal.Client, dependencyID graphql.ID, input structs.StackDependencyReferenceInput) (*structs.StackDependencyReference, error) {
+	var mutation struct {
+		StackDependencyReference structs.StackDependencyReference `graphql:"stackDependenciesAddReference(stackDependencyID: $stackDependencyID, reference: $reference)"`
+	}
+
+	variables := map[string]interface{}{
+		"stackDependencyID": dependencyID,
+		"reference":         input,
+	}
+
+	if err := client.Mutate(ctx, "StackDependenciesAddReference", &mutation, variables); err != nil {
+		return nil, err
+	}
+
+	return &mutation.StackDependencyReference, nil
+}
+
+func updateStackDependencyReference(ctx context.Context, client *internal.Client, input structs.StackDependencyReferenceUpdateInput) (*structs.StackDependencyReference, error) {
+	var mutation struct {
+		StackDependencyReference structs.StackDependencyReference `graphql:"stackDependenciesUpdateReference(reference: $reference)"`
+	}
+
+	variables := map[string]interface{}{"reference": input}
+
+	if err := client.Mutate(ctx, "StackDependenciesUpdateReference", &mutation, variables); err != nil {
+		return nil, err
+	}
+
+	return &mutation.StackDependencyReference, nil
+}
+
+func deleteStackDependencyReference(ctx context.Context, client *internal.Client, referenceID graphql.ID) error {
+	var mutation struct {
+		StackDependencyReference *structs.StackDependencyReference `graphql:"stackDependenciesDeleteReference(id: $id)"`
+	}
+
+	variables := map[string]interface{}{"id": referenceID}
+
+	return client.Mutate(ctx, "StackDependenciesDeleteReference", &mutation, variables)
+}
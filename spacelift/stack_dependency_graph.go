@@ -0,0 +1,227 @@
+package spacelift
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+)
+
+// stackDependencyGraph is the graph of dependencies between stacks, loaded
+// lazily from the API one stack at a time.
+type stackDependencyGraph struct {
+	client *internal.Client
+
+	// dependsOn maps stack IDs to the IDs of the stacks they depend on, and
+	// dependedOnBy to the IDs of the stacks depending on them. Stacks are only
+	// present once loaded.
+	dependsOn    map[string][]string
+	dependedOnBy map[string][]string
+
+	// ignored holds the dependencies to leave out of the graph, keyed by the ID
+	// of the stack and then of the stack it depends on.
+	ignored map[string]map[string]bool
+}
+
+func newStackDependencyGraph(client *internal.Client) *stackDependencyGraph {
+	return &stackDependencyGraph{
+		client:       client,
+		dependsOn:    make(map[string][]string),
+		dependedOnBy: make(map[string][]string),
+		ignored:      make(map[string]map[string]bool),
+	}
+}
+
+// ignore leaves the dependency of one stack on another out of the graph, e.g.
+// because it's about to be replaced.
+func (g *stackDependencyGraph) ignore(stackID, dependsOnStackID string) {
+	if g.ignored[stackID] == nil {
+		g.ignored[stackID] = make(map[string]bool)
+	}
+	g.ignored[stackID][dependsOnStackID] = true
+
+	if dependsOn, ok := g.dependsOn[stackID]; ok {
+		g.dependsOn[stackID] = slices.DeleteFunc(dependsOn, func(id string) bool { return id == dependsOnStackID })
+	}
+	if dependedOnBy, ok := g.dependedOnBy[dependsOnStackID]; ok {
+		g.dependedOnBy[dependsOnStackID] = slices.DeleteFunc(dependedOnBy, func(id string) bool { return id == stackID })
+	}
+}
+
+// load fetches the direct dependencies of the stack, unless they've already
+// been fetched. Stacks which don't exist (yet) have no dependencies.
+func (g *stackDependencyGraph) load(ctx context.Context, stackID string) error {
+	if _, ok := g.dependsOn[stackID]; ok {
+		return nil
+	}
+
+	var query struct {
+		Stack *struct {
+			DependsOn      []structs.StackDependency `graphql:"dependsOn"`
+			IsDependedOnBy []structs.StackDependency `graphql:"isDependedOnBy"`
+		} `graphql:"stack(id: $id)"`
+	}
+
+	variables := map[string]interface{}{"id": toID(stackID)}
+
+	if err := g.client.Query(ctx, "StackDependencyGraph", &query, variables); err != nil {
+		return errors.Wrapf(err, "could not query for dependencies of stack %s", stackID)
+	}
+
+	dependsOn, dependedOnBy := []string{}, []string{}
+
+	if query.Stack != nil {
+		for _, dependency := range query.Stack.DependsOn {
+			if !g.ignored[stackID][dependency.DependsOnStack.ID] {
+				dependsOn = append(dependsOn, dependency.DependsOnStack.ID)
+			}
+		}
+		for _, dependency := range query.Stack.IsDependedOnBy {
+			if !g.ignored[dependency.Stack.ID][stackID] {
+				dependedOnBy = append(dependedOnBy, dependency.Stack.ID)
+			}
+		}
+	}
+
+	slices.Sort(dependsOn)
+	slices.Sort(dependedOnBy)
+
+	g.dependsOn[stackID] = dependsOn
+	g.dependedOnBy[stackID] = dependedOnBy
+
+	return nil
+}
+
+// findPath returns the shortest chain of dependencies leading from one stack
+// to another, including both ends, or nil if there is none.
+func (g *stackDependencyGraph) findPath(ctx context.Context, from, to string) ([]string, error) {
+	if from == to {
+		return []string{from}, nil
+	}
+
+	parents := map[string]string{from: ""}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if err := g.load(ctx, current); err != nil {
+			return nil, err
+		}
+
+		for _, next := range g.dependsOn[current] {
+			if _, seen := parents[next]; seen {
+				continue
+			}
+			parents[next] = current
+
+			if next == to {
+				path := []string{to}
+				for node := current; node != ""; node = parents[node] {
+					path = append([]string{node}, path...)
+				}
+				return path, nil
+			}
+
+			queue = append(queue, next)
+		}
+	}
+
+	return nil, nil
+}
+
+// loadConnected loads all the stacks connected to the given ones, following
+// dependencies in both directions.
+func (g *stackDependencyGraph) loadConnected(ctx context.Context, stackIDs []string) error {
+	queue := slices.Clone(stackIDs)
+	seen := make(map[string]bool)
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+
+		if err := g.load(ctx, current); err != nil {
+			return err
+		}
+
+		queue = append(queue, g.dependsOn[current]...)
+		queue = append(queue, g.dependedOnBy[current]...)
+	}
+
+	return nil
+}
+
+// nodes returns the sorted IDs of the loaded stacks.
+func (g *stackDependencyGraph) nodes() []string {
+	nodes := make([]string, 0, len(g.dependsOn))
+	for stackID := range g.dependsOn {
+		nodes = append(nodes, stackID)
+	}
+	slices.Sort(nodes)
+
+	return nodes
+}
+
+// topologicalOrder returns the loaded stacks ordered so that each one comes
+// after all the stacks it depends on. If the graph contains a cycle, the order
+// is nil and the cycle is returned instead, as a path starting and ending with
+// the same stack.
+func (g *stackDependencyGraph) topologicalOrder() (order, cycle []string) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	status := make(map[string]int)
+	var stack []string
+
+	var visit func(stackID string) []string
+	visit = func(stackID string) []string {
+		status[stackID] = visiting
+		stack = append(stack, stackID)
+
+		for _, dependency := range g.dependsOn[stackID] {
+			switch status[dependency] {
+			case visiting:
+				start := slices.Index(stack, dependency)
+				return append(slices.Clone(stack[start:]), dependency)
+			case unvisited:
+				if cycle := visit(dependency); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		status[stackID] = visited
+		order = append(order, stackID)
+
+		return nil
+	}
+
+	for _, stackID := range g.nodes() {
+		if status[stackID] != unvisited {
+			continue
+		}
+		if cycle := visit(stackID); cycle != nil {
+			return nil, cycle
+		}
+	}
+
+	return order, nil
+}
+
+func formatDependencyPath(path []string) string {
+	return strings.Join(path, " -> ")
+}
@@ -0,0 +1,150 @@
+package spacelift
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// testStackDependencyGraph returns a fully loaded graph with the given
+// dependencies, keyed by the ID of the stack depending on the others.
+func testStackDependencyGraph(dependencies map[string][]string) *stackDependencyGraph {
+	g := newStackDependencyGraph(nil)
+
+	for stackID, dependsOn := range dependencies {
+		g.dependsOn[stackID] = append(g.dependsOn[stackID], dependsOn...)
+		if _, ok := g.dependedOnBy[stackID]; !ok {
+			g.dependedOnBy[stackID] = []string{}
+		}
+
+		for _, dependsOnStackID := range dependsOn {
+			g.dependedOnBy[dependsOnStackID] = append(g.dependedOnBy[dependsOnStackID], stackID)
+			if _, ok := g.dependsOn[dependsOnStackID]; !ok {
+				g.dependsOn[dependsOnStackID] = []string{}
+			}
+		}
+	}
+
+	return g
+}
+
+func Test_stackDependencyGraph_findPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		dependencies map[string][]string
+		ignored      [][2]string
+		from, to     string
+		want         []string
+	}{
+		{
+			name:         "self-loop",
+			dependencies: map[string][]string{"a": nil},
+			from:         "a",
+			to:           "a",
+			want:         []string{"a"},
+		},
+		{
+			name:         "direct dependency",
+			dependencies: map[string][]string{"a": {"b"}},
+			from:         "a",
+			to:           "b",
+			want:         []string{"a", "b"},
+		},
+		{
+			name:         "no path against the direction of dependencies",
+			dependencies: map[string][]string{"a": {"b"}},
+			from:         "b",
+			to:           "a",
+			want:         nil,
+		},
+		{
+			name:         "diamond takes the first shortest path",
+			dependencies: map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}},
+			from:         "a",
+			to:           "d",
+			want:         []string{"a", "b", "d"},
+		},
+		{
+			name:         "longer chain",
+			dependencies: map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"d"}},
+			from:         "a",
+			to:           "d",
+			want:         []string{"a", "b", "c", "d"},
+		},
+		{
+			name:         "disconnected components",
+			dependencies: map[string][]string{"a": {"b"}, "c": {"d"}},
+			from:         "a",
+			to:           "d",
+			want:         nil,
+		},
+		{
+			name:         "ignored dependency",
+			dependencies: map[string][]string{"a": {"b"}, "b": {"c"}},
+			ignored:      [][2]string{{"b", "c"}},
+			from:         "a",
+			to:           "c",
+			want:         nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := testStackDependencyGraph(tt.dependencies)
+			for _, edge := range tt.ignored {
+				g.ignore(edge[0], edge[1])
+			}
+
+			got, err := g.findPath(context.Background(), tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("findPath() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stackDependencyGraph_topologicalOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		dependencies map[string][]string
+		wantOrder    []string
+		wantCycle    []string
+	}{
+		{
+			name:         "self-loop",
+			dependencies: map[string][]string{"a": {"a"}},
+			wantCycle:    []string{"a", "a"},
+		},
+		{
+			name:         "diamond",
+			dependencies: map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}},
+			wantOrder:    []string{"d", "b", "c", "a"},
+		},
+		{
+			name:         "longer cycle",
+			dependencies: map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"d"}, "d": {"b"}},
+			wantCycle:    []string{"b", "c", "d", "b"},
+		},
+		{
+			name:         "disconnected components",
+			dependencies: map[string][]string{"a": {"b"}, "c": {"d"}, "e": nil},
+			wantOrder:    []string{"b", "a", "d", "c", "e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, cycle := testStackDependencyGraph(tt.dependencies).topologicalOrder()
+
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("topologicalOrder() order = %v, want %v", order, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(cycle, tt.wantCycle) {
+				t.Errorf("topologicalOrder() cycle = %v, want %v", cycle, tt.wantCycle)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package spacelift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal"
+	"github.com/spacelift-io/terraform-provider-spacelift/spacelift/internal/structs"
+)
+
+// destroyOnDeleteSchema reuses the options of waiting for a run, except for
+// disabling it: the stack must not be deleted before its resources are gone.
+func destroyOnDeleteSchema() *schema.Schema {
+	fields := waitConfigurationSchema().Elem.(*schema.Resource).Schema
+	delete(fields, "disabled")
+
+	fields["allow_remaining_resources"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Delete the stack even if some resources remain after the destroy run. Default: `false`",
+		Optional:    true,
+		Default:     false,
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Destroy the resources managed by the stack before deleting it. The stack is only deleted if the destroy run ends as expected and, unless allowed, no resources remain. The `delete` timeout bounds the wait for the destroy run. On stacks without autodeploy, use `on_unconfirmed` to confirm the destroy run.",
+		MaxItems:    1,
+		Elem:        &schema.Resource{Schema: fields},
+	}
+}
+
+type destroyOnDeleteConfiguration struct {
+	wait                    *waitConfiguration
+	allowRemainingResources bool
+}
+
+func expandDestroyOnDeleteConfiguration(input []interface{}) *destroyOnDeleteConfiguration {
+	if len(input) == 0 {
+		return nil
+	}
+
+	// An empty block is a valid way of enabling the defaults.
+	v, _ := input[0].(map[string]interface{})
+	if v == nil {
+		v = map[string]interface{}{
+			"continue_on_timeout":       false,
+			"cancel_on_interrupt":       false,
+			"cancel_on_timeout":         false,
+			"allow_remaining_resources": false,
+		}
+	}
+
+	waitInput := map[string]interface{}{"disabled": false}
+	for key, value := range v {
+		waitInput[key] = value
+	}
+
+	return &destroyOnDeleteConfiguration{
+		wait:                    expandWaitConfiguration([]interface{}{waitInput}),
+		allowRemainingResources: v["allow_remaining_resources"].(bool),
+	}
+}
+
+// Destroy triggers a destroy run on the stack and waits for it to end as
+// configured. It returns errors if the stack should not be deleted.
+func (cfg *destroyOnDeleteConfiguration) Destroy(ctx context.Context, client *internal.Client, stackID string, timeout time.Duration) diag.Diagnostics {
+	var mutation struct {
+		RunTrigger struct {
+			ID string `graphql:"id"`
+		} `graphql:"runTrigger(stack: $stack, runType: $runType)"`
+	}
+
+	variables := map[string]interface{}{
+		"stack":   toID(stackID),
+		"runType": structs.RunType("DESTROY"),
+	}
+
+	if err := client.Mutate(ctx, "StackDestroyOnDelete", &mutation, variables); err != nil {
+		return diag.Errorf("could not trigger destroy run for stack %s: %v", stackID, internal.FromSpaceliftError(err))
+	}
+
+	runID := mutation.RunTrigger.ID
+
+	tflog.Info(ctx, "triggered destroy run before deleting stack", map[string]any{
+		"stackID": stackID,
+		"runID":   runID,
+	})
+
+	if diags := cfg.wait.Wait(ctx, timeout, client, stackID, runID); diags.HasError() {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("stack %s was not deleted", stackID),
+			Detail:   fmt.Sprintf("The stack has been left intact because its destroy run %s did not end as expected.", runID),
+		})
+	}
+
+	if cfg.allowRemainingResources {
+		return nil
+	}
+
+	remaining, err := getStackResources(ctx, client, stackID)
+	if err != nil {
+		return diag.Errorf("could not check resources remaining on stack %s after destroy run %s: %v", stackID, runID, internal.FromSpaceliftError(err))
+	}
+
+	if len(remaining) > 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("stack %s was not deleted", stackID),
+			Detail:   fmt.Sprintf("The stack has been left intact because resources remain after its destroy run %s:\n\n%s", runID, strings.Join(remaining, "\n")),
+		}}
+	}
+
+	return nil
+}
+
+// getStackResources returns the addresses of the resources managed by the
+// stack.
+func getStackResources(ctx context.Context, client *internal.Client, stackID string) ([]string, error) {
+	var query struct {
+		Stack *struct {
+			Entities []struct {
+				Address string `graphql:"address"`
+				Type    string `graphql:"type"`
+			} `graphql:"entities"`
+		} `graphql:"stack(id: $id)"`
+	}
+
+	variables := map[string]interface{}{"id": toID(stackID)}
+
+	if err := client.Query(ctx, "StackResources", &query, variables); err != nil {
+		return nil, err
+	}
+
+	if query.Stack == nil {
+		return nil, nil
+	}
+
+	var resources []string
+	for _, entity := range query.Stack.Entities {
+		if entity.Type == "resource" {
+			resources = append(resources, entity.Address)
+		}
+	}
+
+	return resources, nil
+}